@@ -80,6 +80,7 @@ var (
 	// Wallet Flags
 	initForce            bool   // destroy and re-encrypt the wallet on init if it already exists
 	initPassword         bool   // supply a custom password when creating a wallet
+	initWeakSeed         bool   // allow init-seed to use a seed that fails the entropy check
 	walletRawTxn         bool   // Encode/decode transactions in base64-encoded binary.
 	walletStartHeight    uint64 // Start height for transaction search.
 	walletEndHeight      uint64 // End height for transaction search.
@@ -380,6 +381,7 @@ func initCmds() *cobra.Command {
 	walletInitCmd.Flags().BoolVarP(&initPassword, "password", "p", false, "Prompt for a custom password")
 	walletInitCmd.Flags().BoolVarP(&initForce, "force", "", false, "destroy the existing wallet and re-encrypt")
 	walletInitSeedCmd.Flags().BoolVarP(&initForce, "force", "", false, "destroy the existing wallet")
+	walletInitSeedCmd.Flags().BoolVarP(&initWeakSeed, "allow-weak-seed", "", false, "allow a seed with too little entropy, e.g. to restore an existing wallet")
 	walletLoadCmd.AddCommand(walletLoad033xCmd, walletLoadSeedCmd, walletLoadSiagCmd)
 	walletSendCmd.AddCommand(walletSendSiacoinsCmd, walletSendSiafundsCmd)
 	walletSendSiacoinsCmd.Flags().BoolVarP(&walletTxnFeeIncluded, "fee-included", "", false, "Take the transaction fee out of the balance being submitted instead of the fee being additional")
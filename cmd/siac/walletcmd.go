@@ -327,7 +327,11 @@ func walletinitseedcmd() {
 			die(err)
 		}
 	}
-	err = httpClient.WalletInitSeedPost(seed, password, initForce)
+	if initWeakSeed {
+		err = httpClient.WalletInitWeakSeedPost(seed, password, initForce)
+	} else {
+		err = httpClient.WalletInitSeedPost(seed, password, initForce)
+	}
 	if err != nil {
 		die("Could not initialize wallet from seed:", err)
 	}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"runtime/pprof"
 	"strconv"
 	"strings"
 	"syscall"
@@ -182,6 +183,31 @@ func installKillSignalHandler() chan os.Signal {
 	return sigChan
 }
 
+// closeWithTimeout closes the server, exiting the process if it hasn't
+// finished closing after timeout. Before exiting, the stacks of all goroutines
+// are printed to show what was blocking the shutdown. A timeout of 0 waits
+// indefinitely.
+func closeWithTimeout(srv *server.Server, timeout time.Duration) error {
+	if timeout == 0 {
+		return srv.Close()
+	}
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- srv.Close()
+	}()
+	select {
+	case err := <-errChan:
+		return err
+	case <-time.After(timeout):
+		fmt.Fprintf(os.Stderr, "Shutdown did not complete within %v, exiting. Active goroutines:\n", timeout)
+		if err := pprof.Lookup("goroutine").WriteTo(os.Stderr, 1); err != nil {
+			fmt.Fprintln(os.Stderr, "Failed to write goroutine stacks:", err)
+		}
+		os.Exit(exitCodeGeneral)
+		return nil
+	}
+}
+
 // tryAutoUnlock will try to automatically unlock the server's wallet if the
 // environment variable is set.
 func tryAutoUnlock(srv *server.Server) {
@@ -242,7 +268,7 @@ func startDaemon(config Config) (err error) {
 			return err
 		case <-sigChan:
 			fmt.Println("\rCaught stop signal, quitting...")
-			return srv.Close()
+			return closeWithTimeout(srv, config.Siad.ShutdownTimeout)
 		}
 	}()
 	if err != nil {
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -49,6 +50,11 @@ type Config struct {
 		Profile    string
 		ProfileDir string
 
+		// ShutdownTimeout is how long siad waits for its modules to close
+		// after catching a stop signal before it exits forcefully. A
+		// timeout of 0 waits indefinitely.
+		ShutdownTimeout time.Duration
+
 		// NOTE: SiaDir in this case is referencing the directory that siad is
 		// going to be running out of, not the actual siadir, which is where we
 		// put the apipassword file. This variable should not be altered if it
@@ -197,6 +203,7 @@ func main() {
 	root.Flags().BoolVarP(&globalConfig.Siad.NoBootstrap, "no-bootstrap", "", false, "disable bootstrapping on this run")
 	root.Flags().BoolVarP(&globalConfig.Siad.UseUPNP, "upnp", "", true, "use UPnP for port forwarding and external IP discovery")
 	root.Flags().StringVarP(&globalConfig.Siad.Profile, "profile", "", "", "enable profiling with flags 'cmt' for CPU, memory, trace")
+	root.Flags().DurationVarP(&globalConfig.Siad.ShutdownTimeout, "shutdown-timeout", "", 30*time.Second, "how long to wait for a clean shutdown before exiting forcefully, 0 to wait indefinitely")
 	root.Flags().StringVarP(&globalConfig.Siad.RPCaddr, "rpc-addr", "", ":9981", "which port the gateway listens on")
 	root.Flags().StringVarP(&globalConfig.Siad.SiaMuxTCPAddr, "siamux-addr", "", ":9983", "which port the SiaMux listens on")
 	root.Flags().StringVarP(&globalConfig.Siad.SiaMuxWSAddr, "siamux-addr-ws", "", ":9984", "which port the SiaMux websocket listens on")
@@ -69,6 +69,13 @@ func checkTarget(b types.Block, id types.BlockID, target types.Target) bool {
 	return bytes.Compare(target[:], id[:]) >= 0
 }
 
+// VerifyPoW returns true if the ID of the block header meets the given
+// target. It only checks the proof-of-work, so it can be used to audit a
+// header without the rest of the block or the consensus set.
+func VerifyPoW(h types.BlockHeader, target types.Target) bool {
+	return checkTarget(types.Block{}, h.ID(), target)
+}
+
 // ValidateBlock validates a block against a minimum timestamp, a block target,
 // and a block height. Returns nil if the block is valid and an appropriate
 // error otherwise.
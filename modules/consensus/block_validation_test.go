@@ -1,8 +1,13 @@
 package consensus
 
 import (
+	"bytes"
+	"encoding/binary"
+	"math/big"
 	"testing"
 
+	"gitlab.com/NebulousLabs/fastrand"
+
 	"go.sia.tech/siad/types"
 )
 
@@ -161,3 +166,44 @@ func TestCheckTarget(t *testing.T) {
 		t.Error("CheckTarget failed for a same target")
 	}
 }
+
+// TestVerifyPoW checks that VerifyPoW accepts headers whose ID meets the
+// target and rejects headers whose ID is above it.
+func TestVerifyPoW(t *testing.T) {
+	// Pick a header whose ID is in the lower half of the range, so that at
+	// least half of all other nonces produce a higher ID.
+	var header types.BlockHeader
+	header.Timestamp = types.CurrentTimestamp()
+	for {
+		fastrand.Read(header.ParentID[:])
+		if id := header.ID(); id[0] < 128 {
+			break
+		}
+	}
+
+	// Use the header's own ID as the target so that the result doesn't depend
+	// on how easy the target is. The ID itself meets the target, but anything
+	// just below it doesn't.
+	id := header.ID()
+	exact := types.Target(id)
+	if !VerifyPoW(header, exact) {
+		t.Fatal("VerifyPoW rejected a header whose ID equals the target")
+	}
+	below := types.IntToTarget(new(big.Int).Sub(exact.Int(), big.NewInt(1)))
+	if VerifyPoW(header, below) {
+		t.Fatal("VerifyPoW accepted a header whose ID is above the target")
+	}
+
+	// Change the nonce until the ID rises above the original one. That header
+	// must be rejected.
+	for i := uint64(1); ; i++ {
+		binary.LittleEndian.PutUint64(header.Nonce[:], i)
+		newID := header.ID()
+		if bytes.Compare(newID[:], id[:]) > 0 {
+			break
+		}
+	}
+	if VerifyPoW(header, exact) {
+		t.Fatal("VerifyPoW accepted a header with a modified nonce")
+	}
+}
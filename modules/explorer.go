@@ -8,9 +8,67 @@ const (
 	// ExplorerDir is the name of the directory that is typically used for the
 	// explorer.
 	ExplorerDir = "explorer"
+
+	// MaxRichlistLimit is the maximum number of addresses that will be
+	// returned by a call to SiafundRichlist.
+	MaxRichlistLimit = 1000
+
+	// MaxAddressClusterSize is the maximum number of addresses that will be
+	// returned by a call to AddressCluster.
+	MaxAddressClusterSize = 1000
+
+	// MaxAddressClusterDepth is the maximum depth that can be requested in a
+	// call to AddressCluster.
+	MaxAddressClusterDepth = 10
+
+	// MaxAddressClusterTransactions is the maximum number of transactions
+	// that a call to AddressCluster will examine.
+	MaxAddressClusterTransactions = 10000
+
+	// MaxBlockFactsRange is the maximum number of blocks that can be
+	// requested in a single call to BlockFactsRange.
+	MaxBlockFactsRange = 5000
+
+	// MaxLatestBlockFacts is the maximum number of blocks that can be
+	// requested in a single call to LatestBlockFactsN.
+	MaxLatestBlockFacts = 10000
 )
 
 type (
+	// AddressBalance pairs an address with the balance that it holds.
+	AddressBalance struct {
+		UnlockHash types.UnlockHash `json:"unlockhash"`
+		Balance    types.Currency   `json:"balance"`
+	}
+
+	// BalanceSnapshot is the balance of an address after the block at Height
+	// was applied. Only spendable outputs are counted, so miner payouts and
+	// file contract payouts are included once they mature.
+	BalanceSnapshot struct {
+		Height         types.BlockHeight `json:"height"`
+		BlockID        types.BlockID     `json:"blockid"`
+		SiacoinBalance types.Currency    `json:"siacoinbalance"`
+		SiafundBalance types.Currency    `json:"siafundbalance"`
+	}
+
+	// DifficultyAdjustment describes the change in difficulty between the
+	// block at Height-1 and the block at Height. PercentChange is relative to
+	// Before, e.g. 0.1 for a 10% increase.
+	DifficultyAdjustment struct {
+		Height        types.BlockHeight `json:"height"`
+		Before        types.Currency    `json:"before"`
+		After         types.Currency    `json:"after"`
+		PercentChange float64           `json:"percentchange"`
+	}
+
+	// HistogramBucket counts the file contracts whose file size is at most
+	// MaxSize and larger than the MaxSize of the previous bucket.
+	HistogramBucket struct {
+		MaxSize    uint64 `json:"maxsize"`
+		Count      uint64 `json:"count"`
+		TotalBytes uint64 `json:"totalbytes"`
+	}
+
 	// BlockFacts returns a bunch of statistics about the consensus set as they
 	// were at a specific block.
 	BlockFacts struct {
@@ -58,10 +116,25 @@ type (
 		// appeared at a given block.
 		BlockFacts(types.BlockHeight) (BlockFacts, bool)
 
+		// BlockFactsRange returns the block facts for every block between
+		// start and end, inclusive. At most MaxBlockFactsRange blocks can be
+		// requested at once.
+		BlockFactsRange(start, end types.BlockHeight) ([]BlockFacts, error)
+
+		// DifficultyAdjustments returns the difficulty changes between start
+		// and end, inclusive, whose absolute PercentChange exceeds threshold.
+		// At most MaxBlockFactsRange blocks can be scanned at once.
+		DifficultyAdjustments(start, end types.BlockHeight, threshold float64) ([]DifficultyAdjustment, error)
+
 		// LatestBlockFacts returns the block facts of the last block
 		// in the explorer's database.
 		LatestBlockFacts() BlockFacts
 
+		// LatestBlockFactsN returns the block facts of the last n blocks in
+		// the explorer's database, starting with the most recent block. n is
+		// capped at MaxLatestBlockFacts.
+		LatestBlockFactsN(n int) ([]BlockFacts, error)
+
 		// Transaction returns the block that contains the input transaction
 		// id. The transaction itself is either the block (indicating the miner
 		// payouts are somehow involved), or it is a transaction inside of the
@@ -93,6 +166,11 @@ type (
 		// the provided file contract id.
 		FileContractID(types.FileContractID) []types.TransactionID
 
+		// FileSizeHistogram returns the distribution of file contract sizes
+		// over the provided byte thresholds, using the latest revision of
+		// each contract.
+		FileSizeHistogram(thresholds []uint64) ([]HistogramBucket, error)
+
 		// SiafundOutput will return the siafund output associated with the
 		// input id.
 		SiafundOutput(types.SiafundOutputID) (types.SiafundOutput, bool)
@@ -101,6 +179,22 @@ type (
 		// the provided siafund output id.
 		SiafundOutputID(types.SiafundOutputID) []types.TransactionID
 
+		// SiafundRichlist returns up to limit addresses holding siafunds,
+		// ordered by descending balance. The limit is capped at
+		// MaxRichlistLimit.
+		SiafundRichlist(limit int) ([]AddressBalance, error)
+
+		// AddressCluster returns the addresses that have been spent from in
+		// the same transaction as the provided address, following co-spends
+		// up to depth hops. At most MaxAddressClusterSize addresses are
+		// returned, and the search stops after examining
+		// MaxAddressClusterTransactions transactions.
+		AddressCluster(addr types.UnlockHash, depth int) ([]types.UnlockHash, error)
+
+		// BalanceHistory returns the balance of the provided address after
+		// every block in which it changed, ordered by height.
+		BalanceHistory(addr types.UnlockHash) ([]BalanceSnapshot, error)
+
 		Close() error
 	}
 )
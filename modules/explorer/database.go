@@ -11,6 +11,9 @@ import (
 
 var (
 	// database buckets
+	// bucketBalanceHistory holds a bucket for each unlock hash, mapping the
+	// heights at which its balance changed to a modules.BalanceSnapshot
+	bucketBalanceHistory        = []byte("BalanceHistory")
 	bucketBlockFacts            = []byte("BlockFacts")
 	bucketBlockIDs              = []byte("BlockIDs")
 	bucketBlocksDifficulty      = []byte("BlocksDifficulty")
@@ -21,6 +24,9 @@ var (
 	bucketInternal         = []byte("Internal")
 	bucketSiacoinOutputIDs = []byte("SiacoinOutputIDs")
 	bucketSiacoinOutputs   = []byte("SiacoinOutputs")
+	// bucketSiafundBalances maps each unlock hash to the number of siafunds
+	// it currently holds
+	bucketSiafundBalances  = []byte("SiafundBalances")
 	bucketSiafundOutputIDs = []byte("SiafundOutputIDs")
 	bucketSiafundOutputs   = []byte("SiafundOutputs")
 	bucketTransactionIDs   = []byte("TransactionIDs")
@@ -46,6 +46,7 @@ type (
 	Explorer struct {
 		cs         modules.ConsensusSet
 		db         *persist.BoltDatabase
+		log        *persist.Logger
 		persistDir string
 	}
 )
@@ -89,5 +90,10 @@ func New(cs modules.ConsensusSet, persistDir string) (*Explorer, error) {
 // Close closes the explorer.
 func (e *Explorer) Close() error {
 	e.cs.Unsubscribe(e)
-	return e.db.Close()
+	dbErr := e.db.Close()
+	logErr := e.log.Close()
+	if dbErr != nil {
+		return dbErr
+	}
+	return logErr
 }
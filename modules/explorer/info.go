@@ -1,8 +1,14 @@
 package explorer
 
 import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
 	"gitlab.com/NebulousLabs/bolt"
 
+	"gitlab.com/NebulousLabs/encoding"
 	"go.sia.tech/siad/build"
 	"go.sia.tech/siad/modules"
 	"go.sia.tech/siad/types"
@@ -36,6 +42,85 @@ func (e *Explorer) BlockFacts(height types.BlockHeight) (modules.BlockFacts, boo
 	return bf.BlockFacts, true
 }
 
+// BlockFactsRange returns the block facts for every block between start and
+// end, inclusive.
+func (e *Explorer) BlockFactsRange(start, end types.BlockHeight) ([]modules.BlockFacts, error) {
+	if start > end {
+		return nil, errors.New("start height must not be greater than end height")
+	} else if end-start >= modules.MaxBlockFactsRange {
+		return nil, fmt.Errorf("cannot request more than %v blocks at once", modules.MaxBlockFactsRange)
+	}
+
+	facts := make([]modules.BlockFacts, 0, end-start+1)
+	err := e.db.View(func(tx *bolt.Tx) error {
+		for height := start; height <= end; height++ {
+			var bf blockFacts
+			if err := e.dbGetBlockFacts(height, &bf)(tx); err != nil {
+				return err
+			}
+			facts = append(facts, bf.BlockFacts)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return facts, nil
+}
+
+// DifficultyAdjustments compares the difficulty of each block between start and
+// end, inclusive, to that of its parent, and returns the adjustments whose
+// absolute percentage change exceeds threshold.
+func (e *Explorer) DifficultyAdjustments(start, end types.BlockHeight, threshold float64) ([]modules.DifficultyAdjustment, error) {
+	if start > end {
+		return nil, errors.New("start height must not be greater than end height")
+	} else if end-start >= modules.MaxBlockFactsRange {
+		return nil, fmt.Errorf("cannot request more than %v blocks at once", modules.MaxBlockFactsRange)
+	} else if threshold < 0 {
+		return nil, errors.New("threshold must not be negative")
+	}
+	// The genesis block has no parent to compare against.
+	if start == 0 {
+		start = 1
+	}
+
+	var adjustments []modules.DifficultyAdjustment
+	err := e.db.View(func(tx *bolt.Tx) error {
+		if start > end {
+			return nil
+		}
+		var prev blockFacts
+		if err := e.dbGetBlockFacts(start-1, &prev)(tx); err != nil {
+			return err
+		}
+		for height := start; height <= end; height++ {
+			var bf blockFacts
+			if err := e.dbGetBlockFacts(height, &bf)(tx); err != nil {
+				return err
+			}
+			before, _ := prev.Difficulty.Float64()
+			after, _ := bf.Difficulty.Float64()
+			if before != 0 {
+				change := (after - before) / before
+				if math.Abs(change) > threshold {
+					adjustments = append(adjustments, modules.DifficultyAdjustment{
+						Height:        height,
+						Before:        prev.Difficulty,
+						After:         bf.Difficulty,
+						PercentChange: change,
+					})
+				}
+			}
+			prev = bf
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return adjustments, nil
+}
+
 // LatestBlockFacts returns a set of statistics about the blockchain as they appeared
 // at the latest block height in the explorer's consensus set.
 func (e *Explorer) LatestBlockFacts() modules.BlockFacts {
@@ -54,6 +139,42 @@ func (e *Explorer) LatestBlockFacts() modules.BlockFacts {
 	return bf.BlockFacts
 }
 
+// LatestBlockFactsN returns the block facts of the last n blocks in the
+// explorer's consensus set, ordered from the most recent block backwards. n is
+// capped at modules.MaxLatestBlockFacts.
+func (e *Explorer) LatestBlockFactsN(n int) ([]modules.BlockFacts, error) {
+	if n <= 0 {
+		return nil, errors.New("number of blocks must be positive")
+	} else if n > modules.MaxLatestBlockFacts {
+		n = modules.MaxLatestBlockFacts
+	}
+
+	var facts []modules.BlockFacts
+	err := e.db.View(func(tx *bolt.Tx) error {
+		var height types.BlockHeight
+		err := dbGetInternal(internalBlockHeight, &height)(tx)
+		if err != nil {
+			return err
+		}
+		for i := 0; i < n; i++ {
+			var bf blockFacts
+			if err := e.dbGetBlockFacts(height, &bf)(tx); err != nil {
+				return err
+			}
+			facts = append(facts, bf.BlockFacts)
+			if height == 0 {
+				break
+			}
+			height--
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return facts, nil
+}
+
 // Transaction takes a transaction ID and finds the block containing the
 // transaction. Because of the miner payouts, the transaction ID might be a
 // block ID. To find the transaction, iterate through the block.
@@ -185,3 +306,186 @@ func (e *Explorer) SiafundOutputID(id types.SiafundOutputID) []types.Transaction
 	}
 	return ids
 }
+
+// SiafundRichlist returns up to limit addresses that hold siafunds, ordered by
+// descending balance. The limit is capped at modules.MaxRichlistLimit.
+func (e *Explorer) SiafundRichlist(limit int) ([]modules.AddressBalance, error) {
+	if limit <= 0 {
+		return nil, errors.New("richlist limit must be positive")
+	} else if limit > modules.MaxRichlistLimit {
+		limit = modules.MaxRichlistLimit
+	}
+
+	var balances []modules.AddressBalance
+	err := e.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketSiafundBalances).ForEach(func(k, v []byte) error {
+			var ab modules.AddressBalance
+			if err := encoding.Unmarshal(k, &ab.UnlockHash); err != nil {
+				return err
+			}
+			if err := encoding.Unmarshal(v, &ab.Balance); err != nil {
+				return err
+			}
+			balances = append(balances, ab)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Sort by descending balance, breaking ties by address so that the order
+	// is deterministic.
+	sort.Slice(balances, func(i, j int) bool {
+		if c := balances[i].Balance.Cmp(balances[j].Balance); c != 0 {
+			return c > 0
+		}
+		return balances[i].UnlockHash.String() < balances[j].UnlockHash.String()
+	})
+	if len(balances) > limit {
+		balances = balances[:limit]
+	}
+	return balances, nil
+}
+
+// AddressCluster returns the addresses that have been spent from in the same
+// transaction as addr, which likely belong to the same wallet. Co-spends are
+// followed recursively up to depth hops, and the search stops once
+// modules.MaxAddressClusterSize addresses have been found or
+// modules.MaxAddressClusterTransactions transactions have been examined.
+func (e *Explorer) AddressCluster(addr types.UnlockHash, depth int) ([]types.UnlockHash, error) {
+	return e.addressCluster(addr, depth, modules.MaxAddressClusterTransactions)
+}
+
+// addressCluster is AddressCluster, examining at most maxTxns transactions.
+func (e *Explorer) addressCluster(addr types.UnlockHash, depth, maxTxns int) ([]types.UnlockHash, error) {
+	if depth <= 0 {
+		return nil, errors.New("cluster depth must be positive")
+	}
+
+	seen := map[types.UnlockHash]struct{}{addr: {}}
+	var cluster []types.UnlockHash
+	frontier := []types.UnlockHash{addr}
+	scanned := 0
+	for i := 0; i < depth && len(frontier) > 0; i++ {
+		var next []types.UnlockHash
+		for _, uh := range frontier {
+			for _, txid := range e.UnlockHash(uh) {
+				if scanned >= maxTxns {
+					return cluster, nil
+				}
+				scanned++
+
+				// Find the transaction within its block. The id may belong
+				// to a block's miner payouts, which have no inputs.
+				block, _, exists := e.Transaction(txid)
+				if !exists {
+					continue
+				}
+				for _, txn := range block.Transactions {
+					if txn.ID() != txid {
+						continue
+					}
+					inputs := inputUnlockHashes(txn)
+					spent := false
+					for _, input := range inputs {
+						spent = spent || input == uh
+					}
+					if !spent {
+						break
+					}
+					for _, input := range inputs {
+						if _, ok := seen[input]; ok {
+							continue
+						}
+						seen[input] = struct{}{}
+						cluster = append(cluster, input)
+						next = append(next, input)
+						if len(cluster) >= modules.MaxAddressClusterSize {
+							return cluster, nil
+						}
+					}
+					break
+				}
+			}
+		}
+		frontier = next
+	}
+	return cluster, nil
+}
+
+// BalanceHistory returns the balance of addr after every block in which it
+// changed, ordered by height. An empty history indicates that addr has never
+// held any siacoins or siafunds.
+func (e *Explorer) BalanceHistory(addr types.UnlockHash) ([]modules.BalanceSnapshot, error) {
+	var history []modules.BalanceSnapshot
+	err := e.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketBalanceHistory).Bucket(encoding.Marshal(addr))
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(_, v []byte) error {
+			var snapshot modules.BalanceSnapshot
+			if err := encoding.Unmarshal(v, &snapshot); err != nil {
+				return err
+			}
+			history = append(history, snapshot)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// FileSizeHistogram groups every file contract known to the explorer by the
+// file size of its latest revision. Each bucket counts the contracts larger
+// than the previous threshold and no larger than its own; a final bucket with
+// MaxSize math.MaxUint64 counts the contracts larger than every threshold.
+func (e *Explorer) FileSizeHistogram(thresholds []uint64) ([]modules.HistogramBucket, error) {
+	for i := 1; i < len(thresholds); i++ {
+		if thresholds[i] <= thresholds[i-1] {
+			return nil, errors.New("histogram thresholds must be strictly increasing")
+		}
+	}
+	buckets := make([]modules.HistogramBucket, len(thresholds)+1)
+	for i, t := range thresholds {
+		buckets[i].MaxSize = t
+	}
+	buckets[len(thresholds)].MaxSize = math.MaxUint64
+
+	err := e.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketFileContractHistories).ForEach(func(_, v []byte) error {
+			var history fileContractHistory
+			if err := encoding.Unmarshal(v, &history); err != nil {
+				return err
+			}
+			size := history.Contract.FileSize
+			if n := len(history.Revisions); n > 0 {
+				size = history.Revisions[n-1].NewFileSize
+			}
+			i := sort.Search(len(thresholds), func(i int) bool { return size <= thresholds[i] })
+			buckets[i].Count++
+			buckets[i].TotalBytes += size
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return buckets, nil
+}
+
+// inputUnlockHashes returns the addresses that a transaction spends siacoins
+// or siafunds from, in the order that they appear.
+func inputUnlockHashes(txn types.Transaction) []types.UnlockHash {
+	var uhs []types.UnlockHash
+	for _, sci := range txn.SiacoinInputs {
+		uhs = append(uhs, sci.UnlockConditions.UnlockHash())
+	}
+	for _, sfi := range txn.SiafundInputs {
+		uhs = append(uhs, sfi.UnlockConditions.UnlockHash())
+	}
+	return uhs
+}
@@ -1,11 +1,17 @@
 package explorer
 
 import (
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"gitlab.com/NebulousLabs/bolt"
 	"gitlab.com/NebulousLabs/fastrand"
 
 	"go.sia.tech/siad/crypto"
+	"go.sia.tech/siad/modules"
 	"go.sia.tech/siad/types"
 )
 
@@ -269,3 +275,656 @@ func TestFileContractsPayoutValidProof(t *testing.T) {
 		t.Errorf("expected %v, got %v ", fc.MissedProofOutputs, outputs)
 	}
 }
+
+// TestSiafundRichlist checks that the siafund richlist reports the addresses
+// holding siafunds in order of descending balance.
+func TestSiafundRichlist(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	et, err := createExplorerTester(t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Distribute the anyone-can-spend genesis siafund output (only available
+	// during testing) across 5 addresses.
+	values := []uint64{150, 50, 400, 100, 300}
+	txn := types.Transaction{
+		SiafundInputs: []types.SiafundInput{{
+			ParentID:         types.GenesisBlock.Transactions[0].SiafundOutputID(2),
+			UnlockConditions: types.UnlockConditions{},
+		}},
+	}
+	for _, v := range values {
+		var uh types.UnlockHash
+		fastrand.Read(uh[:])
+		txn.SiafundOutputs = append(txn.SiafundOutputs, types.SiafundOutput{
+			Value:      types.NewCurrency64(v),
+			UnlockHash: uh,
+		})
+	}
+	err = et.tpool.AcceptTransactionSet([]types.Transaction{txn})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = et.miner.AddBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The two remaining genesis outputs should be followed by the 5 new
+	// addresses, and the spent output should no longer appear.
+	richlist, err := et.explorer.SiafundRichlist(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []uint64{7000, 2000, 400, 300, 150, 100, 50}
+	if len(richlist) != len(expected) {
+		t.Fatalf("expected %v addresses, got %v", len(expected), len(richlist))
+	}
+	for i, ab := range richlist {
+		if !ab.Balance.Equals64(expected[i]) {
+			t.Errorf("entry %v: expected balance %v, got %v", i, expected[i], ab.Balance)
+		}
+		if ab.UnlockHash == (types.UnlockConditions{}).UnlockHash() {
+			t.Error("spent output still appears in the richlist")
+		}
+	}
+
+	// The limit should be respected.
+	richlist, err = et.explorer.SiafundRichlist(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(richlist) != 3 {
+		t.Fatal("expected 3 addresses, got", len(richlist))
+	}
+	if _, err := et.explorer.SiafundRichlist(0); err == nil {
+		t.Error("expected an error for a non-positive limit")
+	}
+}
+
+// TestAddressCluster checks that AddressCluster follows co-spent inputs up to
+// the requested depth.
+func TestAddressCluster(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	et, err := createExplorerTester(t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Unlock conditions without public keys can be spent without signatures,
+	// and a distinct timelock gives each a distinct address.
+	ucA := types.UnlockConditions{Timelock: 1}
+	ucB := types.UnlockConditions{Timelock: 2}
+	ucC := types.UnlockConditions{Timelock: 3}
+	addrA, addrB, addrC := ucA.UnlockHash(), ucB.UnlockHash(), ucC.UnlockHash()
+
+	// Fund A, C and B twice so that B can co-spend with both A and C.
+	value := types.SiacoinPrecision.Mul64(1000)
+	txns, err := et.wallet.SendSiacoinsMulti([]types.SiacoinOutput{
+		{Value: value, UnlockHash: addrA},
+		{Value: value, UnlockHash: addrB},
+		{Value: value, UnlockHash: addrB},
+		{Value: value, UnlockHash: addrC},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = et.miner.AddBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var inputs []types.SiacoinInput
+	funding := txns[len(txns)-1]
+	for i, sco := range funding.SiacoinOutputs {
+		for _, uc := range []types.UnlockConditions{ucA, ucB, ucC} {
+			if sco.UnlockHash == uc.UnlockHash() {
+				inputs = append(inputs, types.SiacoinInput{
+					ParentID:         funding.SiacoinOutputID(uint64(i)),
+					UnlockConditions: uc,
+				})
+			}
+		}
+	}
+	if len(inputs) != 4 {
+		t.Fatal("expected 4 funded outputs, got", len(inputs))
+	}
+
+	// Spend A with B, and B with C.
+	var dest types.UnlockHash
+	fastrand.Read(dest[:])
+	for _, pair := range [][]types.SiacoinInput{inputs[:2], inputs[2:]} {
+		txn := types.Transaction{
+			SiacoinInputs: pair,
+			SiacoinOutputs: []types.SiacoinOutput{{
+				Value:      value.Mul64(2),
+				UnlockHash: dest,
+			}},
+		}
+		err = et.tpool.AcceptTransactionSet([]types.Transaction{txn})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, err = et.miner.AddBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A depth of 1 should only reach B, a depth of 2 should reach C as well.
+	cluster, err := et.explorer.AddressCluster(addrA, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cluster) != 1 || cluster[0] != addrB {
+		t.Fatal("wrong cluster at depth 1:", cluster)
+	}
+	cluster, err = et.explorer.AddressCluster(addrA, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cluster) != 2 || cluster[0] != addrB || cluster[1] != addrC {
+		t.Fatal("wrong cluster at depth 2:", cluster)
+	}
+
+	// The destination was never spent from, so it has no cluster.
+	cluster, err = et.explorer.AddressCluster(dest, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cluster) != 0 {
+		t.Fatal("expected an empty cluster, got", cluster)
+	}
+	if _, err := et.explorer.AddressCluster(addrA, 0); err == nil {
+		t.Error("expected an error for a non-positive depth")
+	}
+
+	// The search should stop once it has examined too many transactions.
+	// Examining only the transactions of A should not reach C.
+	cluster, err = et.explorer.addressCluster(addrA, 2, len(et.explorer.UnlockHash(addrA)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cluster) != 1 || cluster[0] != addrB {
+		t.Fatal("wrong cluster with limited transactions:", cluster)
+	}
+}
+
+// TestBalanceHistory checks that BalanceHistory records the balance of an
+// address after every block in which it changed, and that the history is
+// rebuilt for databases created before it was tracked.
+func TestBalanceHistory(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	et, err := createExplorerTester(t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Fund an address that can be spent without signatures.
+	uc := types.UnlockConditions{Timelock: 1}
+	addr := uc.UnlockHash()
+	value := types.SiacoinPrecision.Mul64(1000)
+	txns, err := et.wallet.SendSiacoins(value, addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = et.miner.AddBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fundHeight := et.cs.Height()
+	var input types.SiacoinInput
+	funding := txns[len(txns)-1]
+	for i, sco := range funding.SiacoinOutputs {
+		if sco.UnlockHash == addr {
+			input = types.SiacoinInput{
+				ParentID:         funding.SiacoinOutputID(uint64(i)),
+				UnlockConditions: uc,
+			}
+		}
+	}
+
+	// Mine an unrelated block, then spend most of the balance.
+	_, err = et.miner.AddBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dest types.UnlockHash
+	fastrand.Read(dest[:])
+	sent := types.SiacoinPrecision.Mul64(600)
+	txn := types.Transaction{
+		SiacoinInputs: []types.SiacoinInput{input},
+		SiacoinOutputs: []types.SiacoinOutput{
+			{Value: sent, UnlockHash: dest},
+			{Value: value.Sub(sent), UnlockHash: addr},
+		},
+	}
+	err = et.tpool.AcceptTransactionSet([]types.Transaction{txn})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = et.miner.AddBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	spendHeight := et.cs.Height()
+
+	checkHistory := func(e *Explorer) {
+		t.Helper()
+		history, err := e.BalanceHistory(addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(history) != 2 {
+			t.Fatalf("expected 2 snapshots, got %v", len(history))
+		}
+		if history[0].Height != fundHeight || !history[0].SiacoinBalance.Equals(value) {
+			t.Errorf("expected %v at height %v, got %v at height %v", value, fundHeight, history[0].SiacoinBalance, history[0].Height)
+		}
+		if history[1].Height != spendHeight || !history[1].SiacoinBalance.Equals(value.Sub(sent)) {
+			t.Errorf("expected %v at height %v, got %v at height %v", value.Sub(sent), spendHeight, history[1].SiacoinBalance, history[1].Height)
+		}
+		block, exists := et.cs.BlockAtHeight(spendHeight)
+		if !exists || history[1].BlockID != block.ID() {
+			t.Error("snapshot has the wrong block ID")
+		}
+		if !history[1].SiafundBalance.IsZero() {
+			t.Error("expected no siafunds, got", history[1].SiafundBalance)
+		}
+		history, err = e.BalanceHistory(dest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(history) != 1 || !history[0].SiacoinBalance.Equals(sent) {
+			t.Errorf("unexpected history for destination: %v", history)
+		}
+	}
+	checkHistory(et.explorer)
+
+	// A v0.5.2 database has no balance history, and should cause the
+	// explorer to rescan the blockchain when it is reopened.
+	err = et.explorer.db.Update(func(tx *bolt.Tx) error {
+		err := tx.Bucket([]byte("Metadata")).Put([]byte("Version"), []byte(explorerMetadataV052.Version))
+		if err != nil {
+			return err
+		}
+		return tx.DeleteBucket(bucketBalanceHistory)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = et.explorer.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := New(et.cs, filepath.Join(et.testdir, modules.ExplorerDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := e.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	checkHistory(e)
+	if facts := e.LatestBlockFacts(); facts.Height != et.cs.Height() {
+		t.Errorf("expected facts at height %v after rescan, got %v", et.cs.Height(), facts.Height)
+	}
+	logContents, err := ioutil.ReadFile(filepath.Join(et.testdir, modules.ExplorerDir, logFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(logContents), "rescanning the blockchain") {
+		t.Error("rebuild of the database was not logged")
+	}
+}
+
+// TestBlockFactsRange checks that a range of block facts can be fetched in a
+// single call.
+func TestBlockFactsRange(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	et, err := createExplorerTester(t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Mine some more blocks and fetch the facts for all of them.
+	for i := 0; i < 10; i++ {
+		_, err = et.miner.AddBlock()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	height := et.cs.Height()
+	facts, err := et.explorer.BlockFactsRange(1, height)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if types.BlockHeight(len(facts)) != height {
+		t.Fatalf("expected %v facts, got %v", height, len(facts))
+	}
+	for i, bf := range facts {
+		if bf.Height != types.BlockHeight(i+1) {
+			t.Errorf("expected height %v, got %v", i+1, bf.Height)
+		}
+		if bf.MinerPayoutCount == 0 {
+			t.Errorf("block %v has no miner payouts", bf.Height)
+		}
+	}
+
+	// Invalid ranges should be rejected.
+	if _, err := et.explorer.BlockFactsRange(2, 1); err == nil {
+		t.Error("expected an error for an inverted range")
+	}
+	if _, err := et.explorer.BlockFactsRange(0, height+1); err == nil {
+		t.Error("expected an error for a range past the current height")
+	}
+	if _, err := et.explorer.BlockFactsRange(0, modules.MaxBlockFactsRange); err == nil {
+		t.Error("expected an error for a range that is too large")
+	}
+}
+
+// TestLatestBlockFactsN checks that the facts of the most recent blocks are
+// returned starting with the chain tip.
+func TestLatestBlockFactsN(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	et, err := createExplorerTester(t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 20; i++ {
+		_, err = et.miner.AddBlock()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tip := et.cs.Height()
+	facts, err := et.explorer.LatestBlockFactsN(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(facts) != 10 {
+		t.Fatal("expected 10 facts, got", len(facts))
+	}
+	for i, bf := range facts {
+		if bf.Height != tip-types.BlockHeight(i) {
+			t.Errorf("expected height %v, got %v", tip-types.BlockHeight(i), bf.Height)
+		}
+	}
+	if facts[0].BlockID != et.explorer.LatestBlockFacts().BlockID {
+		t.Error("first entry does not match the latest block facts")
+	}
+
+	// Asking for more blocks than exist should stop at the genesis block.
+	facts, err = et.explorer.LatestBlockFactsN(int(tip) + 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if types.BlockHeight(len(facts)) != tip+1 || facts[len(facts)-1].Height != 0 {
+		t.Fatal("expected facts back to the genesis block, got", len(facts))
+	}
+	if _, err := et.explorer.LatestBlockFactsN(0); err == nil {
+		t.Error("expected an error for a non-positive n")
+	}
+}
+
+// TestDifficultyAdjustments checks that DifficultyAdjustments only reports
+// changes in difficulty that exceed the threshold.
+func TestDifficultyAdjustments(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	et, err := createExplorerTester(t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 10; i++ {
+		_, err = et.miner.AddBlock()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Overwrite the difficulty of each block so that there is a 10% increase
+	// at height 5 and a 2% decrease at height 8.
+	difficulties := map[types.BlockHeight]uint64{5: 1100, 6: 1100, 7: 1100, 8: 1078, 9: 1078, 10: 1078}
+	err = et.explorer.db.Update(func(tx *bolt.Tx) error {
+		for height := types.BlockHeight(0); height <= 10; height++ {
+			var bf blockFacts
+			if err := et.explorer.dbGetBlockFacts(height, &bf)(tx); err != nil {
+				return err
+			}
+			bf.Difficulty = types.NewCurrency64(1000)
+			if d, ok := difficulties[height]; ok {
+				bf.Difficulty = types.NewCurrency64(d)
+			}
+			dbAddBlockFacts(tx, bf)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	adjustments, err := et.explorer.DifficultyAdjustments(0, 10, 0.05)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(adjustments) != 1 {
+		t.Fatal("expected 1 adjustment, got", len(adjustments))
+	}
+	adj := adjustments[0]
+	if adj.Height != 5 || !adj.Before.Equals64(1000) || !adj.After.Equals64(1100) {
+		t.Fatalf("unexpected adjustment %+v", adj)
+	}
+	if math.Abs(adj.PercentChange-0.1) > 1e-9 {
+		t.Fatal("expected a 10% change, got", adj.PercentChange)
+	}
+
+	// A lower threshold should also pick up the decrease.
+	adjustments, err = et.explorer.DifficultyAdjustments(0, 10, 0.01)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(adjustments) != 2 || adjustments[1].Height != 8 || adjustments[1].PercentChange >= 0 {
+		t.Fatalf("unexpected adjustments %+v", adjustments)
+	}
+
+	// Starting the range after the increase should exclude it.
+	adjustments, err = et.explorer.DifficultyAdjustments(6, 10, 0.05)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(adjustments) != 0 {
+		t.Fatal("expected no adjustments, got", len(adjustments))
+	}
+
+	if _, err := et.explorer.DifficultyAdjustments(2, 1, 0); err == nil {
+		t.Error("expected an error for an inverted range")
+	}
+}
+
+// TestFileSizeHistogram checks that FileSizeHistogram groups contracts by the
+// file size of their latest revision.
+func TestFileSizeHistogram(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	et, err := createExplorerTester(t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Create contracts of known sizes.
+	sizes := []uint64{10, 1 << 20, 5 << 20, 2 << 30}
+	payout := types.NewCurrency64(1e9)
+	builder, err := et.wallet.StartTransaction()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = builder.FundSiacoins(payout.Mul64(uint64(len(sizes))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fcs []types.FileContract
+	var indices []uint64
+	for _, size := range sizes {
+		fc := types.FileContract{
+			FileSize:           size,
+			WindowStart:        et.cs.Height() + 10,
+			WindowEnd:          et.cs.Height() + 15,
+			Payout:             payout,
+			ValidProofOutputs:  []types.SiacoinOutput{{Value: types.PostTax(et.cs.Height(), payout)}},
+			MissedProofOutputs: []types.SiacoinOutput{{Value: types.PostTax(et.cs.Height(), payout)}},
+			UnlockHash:         types.UnlockConditions{}.UnlockHash(),
+		}
+		fcs = append(fcs, fc)
+		indices = append(indices, builder.AddFileContract(fc))
+	}
+	tSet, err := builder.Sign(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := et.tpool.AcceptTransactionSet(tSet); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := et.miner.AddBlock(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Revise the smallest contract so that it belongs in the largest bucket.
+	fc := fcs[0]
+	revisionTxn := types.Transaction{
+		FileContractRevisions: []types.FileContractRevision{{
+			ParentID:              tSet[len(tSet)-1].FileContractID(indices[0]),
+			NewRevisionNumber:     1,
+			NewFileSize:           3 << 30,
+			NewWindowStart:        fc.WindowStart,
+			NewWindowEnd:          fc.WindowEnd,
+			NewValidProofOutputs:  fc.ValidProofOutputs,
+			NewMissedProofOutputs: fc.MissedProofOutputs,
+			NewUnlockHash:         fc.UnlockHash,
+		}},
+	}
+	if err := et.tpool.AcceptTransactionSet([]types.Transaction{revisionTxn}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := et.miner.AddBlock(); err != nil {
+		t.Fatal(err)
+	}
+
+	buckets, err := et.explorer.FileSizeHistogram([]uint64{1 << 20, 1 << 30})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []modules.HistogramBucket{
+		{MaxSize: 1 << 20, Count: 1, TotalBytes: 1 << 20},
+		{MaxSize: 1 << 30, Count: 1, TotalBytes: 5 << 20},
+		{MaxSize: math.MaxUint64, Count: 2, TotalBytes: 5 << 30},
+	}
+	if len(buckets) != len(expected) {
+		t.Fatalf("expected %v buckets, got %v", len(expected), len(buckets))
+	}
+	for i := range expected {
+		if buckets[i] != expected[i] {
+			t.Errorf("bucket %v: expected %v, got %v", i, expected[i], buckets[i])
+		}
+	}
+
+	// Thresholds must be increasing.
+	if _, err := et.explorer.FileSizeHistogram([]uint64{1 << 30, 1 << 20}); err == nil {
+		t.Fatal("expected error for decreasing thresholds")
+	}
+}
+
+// TestFileContractHistoryRevisions checks that FileContractHistory returns the
+// original contract along with every revision of it in order.
+func TestFileContractHistoryRevisions(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	et, err := createExplorerTester(t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Form a contract.
+	payout := types.NewCurrency64(1e9)
+	builder, err := et.wallet.StartTransaction()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = builder.FundSiacoins(payout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc := types.FileContract{
+		WindowStart:        et.cs.Height() + 10,
+		WindowEnd:          et.cs.Height() + 15,
+		Payout:             payout,
+		ValidProofOutputs:  []types.SiacoinOutput{{Value: types.PostTax(et.cs.Height(), payout)}},
+		MissedProofOutputs: []types.SiacoinOutput{{Value: types.PostTax(et.cs.Height(), payout)}},
+		UnlockHash:         types.UnlockConditions{}.UnlockHash(),
+	}
+	fcIndex := builder.AddFileContract(fc)
+	tSet, err := builder.Sign(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := et.tpool.AcceptTransactionSet(tSet); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := et.miner.AddBlock(); err != nil {
+		t.Fatal(err)
+	}
+	fcid := tSet[len(tSet)-1].FileContractID(fcIndex)
+
+	// Revise the contract 3 times, each in its own block.
+	for i := uint64(1); i <= 3; i++ {
+		txn := types.Transaction{
+			FileContractRevisions: []types.FileContractRevision{{
+				ParentID:              fcid,
+				NewRevisionNumber:     i,
+				NewFileSize:           i * modules.SectorSize,
+				NewWindowStart:        fc.WindowStart,
+				NewWindowEnd:          fc.WindowEnd,
+				NewValidProofOutputs:  fc.ValidProofOutputs,
+				NewMissedProofOutputs: fc.MissedProofOutputs,
+				NewUnlockHash:         fc.UnlockHash,
+			}},
+		}
+		if err := et.tpool.AcceptTransactionSet([]types.Transaction{txn}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := et.miner.AddBlock(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	history, revisions, exists, proofExists := et.explorer.FileContractHistory(fcid)
+	if !exists || proofExists {
+		t.Fatal("expected contract to exist without a storage proof", exists, proofExists)
+	}
+	if history.FileSize != 0 || history.RevisionNumber != 0 {
+		t.Fatal("original contract was not preserved")
+	}
+	if len(revisions) != 3 {
+		t.Fatalf("expected 3 revisions, got %v", len(revisions))
+	}
+	for i, fcr := range revisions {
+		if fcr.NewRevisionNumber != uint64(i+1) || fcr.NewFileSize != uint64(i+1)*modules.SectorSize {
+			t.Errorf("revision %v is out of order: %v", i, fcr.NewRevisionNumber)
+		}
+	}
+}
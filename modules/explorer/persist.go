@@ -7,15 +7,30 @@ import (
 	"gitlab.com/NebulousLabs/bolt"
 
 	"gitlab.com/NebulousLabs/encoding"
+	"gitlab.com/NebulousLabs/errors"
 	"go.sia.tech/siad/modules"
 	"go.sia.tech/siad/persist"
 	"go.sia.tech/siad/types"
 )
 
-var explorerMetadata = persist.Metadata{
-	Header:  "Sia Explorer",
-	Version: "0.5.2",
-}
+const (
+	dbFile  = modules.ExplorerDir + ".db"
+	logFile = modules.ExplorerDir + ".log"
+)
+
+var (
+	explorerMetadata = persist.Metadata{
+		Header:  "Sia Explorer",
+		Version: "0.5.3",
+	}
+
+	// explorerMetadataV052 is the metadata of databases created before
+	// balance history was tracked.
+	explorerMetadataV052 = persist.Metadata{
+		Header:  "Sia Explorer",
+		Version: "0.5.2",
+	}
+)
 
 // initPersist initializes the persistent structures of the explorer module.
 func (e *Explorer) initPersist() error {
@@ -25,8 +40,22 @@ func (e *Explorer) initPersist() error {
 		return err
 	}
 
+	// Initialize the logger.
+	e.log, err = persist.NewFileLogger(filepath.Join(e.persistDir, logFile))
+	if err != nil {
+		return err
+	}
+
 	// Open the database
-	db, err := persist.OpenDatabase(explorerMetadata, filepath.Join(e.persistDir, "explorer.db"))
+	dbPath := filepath.Join(e.persistDir, dbFile)
+	db, err := persist.OpenDatabase(explorerMetadata, dbPath)
+	if errors.Contains(err, persist.ErrBadVersion) {
+		err = e.convertPersistV052ToV053(dbPath)
+		if err != nil {
+			return errors.AddContext(err, "failed to convert explorer database from v0.5.2 to v0.5.3")
+		}
+		db, err = persist.OpenDatabase(explorerMetadata, dbPath)
+	}
 	if err != nil {
 		return err
 	}
@@ -35,6 +64,7 @@ func (e *Explorer) initPersist() error {
 	// Initialize the database
 	err = e.db.Update(func(tx *bolt.Tx) error {
 		buckets := [][]byte{
+			bucketBalanceHistory,
 			bucketBlockFacts,
 			bucketBlockIDs,
 			bucketBlocksDifficulty,
@@ -44,6 +74,7 @@ func (e *Explorer) initPersist() error {
 			bucketInternal,
 			bucketSiacoinOutputIDs,
 			bucketSiacoinOutputs,
+			bucketSiafundBalances,
 			bucketSiafundOutputIDs,
 			bucketSiafundOutputs,
 			bucketTransactionIDs,
@@ -73,7 +104,6 @@ func (e *Explorer) initPersist() error {
 				return err
 			}
 		}
-
 		return nil
 	})
 	if err != nil {
@@ -82,3 +112,18 @@ func (e *Explorer) initPersist() error {
 
 	return nil
 }
+
+// convertPersistV052ToV053 removes a v0.5.2 database. Balance history can
+// only be recorded as blocks are processed, so the explorer has to rescan the
+// blockchain from the beginning to build it.
+func (e *Explorer) convertPersistV052ToV053(dbPath string) error {
+	db, err := persist.OpenDatabase(explorerMetadataV052, dbPath)
+	if err != nil {
+		return err
+	}
+	if err := db.Close(); err != nil {
+		return err
+	}
+	e.log.Println("Explorer database predates balance history; rescanning the blockchain to rebuild it")
+	return os.Remove(dbPath)
+}
@@ -1,6 +1,7 @@
 package explorer
 
 import (
+	"encoding/binary"
 	"fmt"
 
 	"gitlab.com/NebulousLabs/bolt"
@@ -27,10 +28,14 @@ func (e *Explorer) ProcessConsensusChange(cc modules.ConsensusChange) {
 		}()
 
 		// Update cumulative stats for reverted blocks.
-		for _, block := range cc.RevertedBlocks {
+		for i, block := range cc.RevertedBlocks {
 			bid := block.ID()
 			tbid := types.TransactionID(bid)
 
+			var height types.BlockHeight
+			assertNil(dbGetAndDecode(bucketBlockIDs, bid, &height)(tx))
+			dbRemoveBalanceSnapshots(tx, height, cc.RevertedDiffs[i])
+
 			dbRemoveBlockID(tx, bid)
 			dbRemoveTransactionID(tx, tbid) // Miner payouts are a transaction
 
@@ -116,18 +121,20 @@ func (e *Explorer) ProcessConsensusChange(cc modules.ConsensusChange) {
 
 		blockheight := cc.InitialHeight()
 		// Update cumulative stats for applied blocks.
-		for _, block := range cc.AppliedBlocks {
+		for i, block := range cc.AppliedBlocks {
 			bid := block.ID()
 			tbid := types.TransactionID(bid)
 
 			// special handling for genesis block
 			if bid == types.GenesisID {
 				dbAddGenesisBlock(tx)
+				dbAddBalanceSnapshots(tx, bid, 0, cc.AppliedDiffs[i])
 				continue
 			}
 
 			blockheight++
 			dbAddBlockID(tx, bid, blockheight)
+			dbAddBalanceSnapshots(tx, bid, blockheight, cc.AppliedDiffs[i])
 			dbAddTransactionID(tx, tbid, blockheight) // Miner payouts are a transaction
 
 			target, exists := e.cs.ChildTarget(block.ParentID)
@@ -224,6 +231,9 @@ func (e *Explorer) ProcessConsensusChange(cc modules.ConsensusChange) {
 		for _, sfod := range cc.SiafundOutputDiffs {
 			if sfod.Direction == modules.DiffApply {
 				dbAddSiafundOutput(tx, sfod.ID, sfod.SiafundOutput)
+				dbAddSiafundBalance(tx, sfod.SiafundOutput.UnlockHash, sfod.SiafundOutput.Value)
+			} else {
+				dbRemoveSiafundBalance(tx, sfod.SiafundOutput.UnlockHash, sfod.SiafundOutput.Value)
 			}
 		}
 
@@ -387,6 +397,98 @@ func dbAddSiafundOutput(tx *bolt.Tx, id types.SiafundOutputID, output types.Siaf
 	mustPut(tx.Bucket(bucketSiafundOutputs), id, output)
 }
 
+// Add/Remove siafund balance
+func dbAddSiafundBalance(tx *bolt.Tx, uh types.UnlockHash, value types.Currency) {
+	var balance types.Currency
+	if err := dbGetAndDecode(bucketSiafundBalances, uh, &balance)(tx); err != nil && err != errNotExist {
+		panic(err)
+	}
+	mustPut(tx.Bucket(bucketSiafundBalances), uh, balance.Add(value))
+}
+func dbRemoveSiafundBalance(tx *bolt.Tx, uh types.UnlockHash, value types.Currency) {
+	var balance types.Currency
+	assertNil(dbGetAndDecode(bucketSiafundBalances, uh, &balance)(tx))
+	balance = balance.Sub(value)
+	if balance.IsZero() {
+		mustDelete(tx.Bucket(bucketSiafundBalances), uh)
+	} else {
+		mustPut(tx.Bucket(bucketSiafundBalances), uh, balance)
+	}
+}
+
+// Add/Remove balance snapshots
+//
+// The snapshots of each address are keyed by big-endian height so that the
+// most recent snapshot is always the last key in the bucket.
+func balanceHistoryKey(height types.BlockHeight) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, uint64(height))
+	return key
+}
+func dbAddBalanceSnapshots(tx *bolt.Tx, bid types.BlockID, height types.BlockHeight, diffs modules.ConsensusChangeDiffs) {
+	type balanceChange struct {
+		scAdded, scRemoved types.Currency
+		sfAdded, sfRemoved types.Currency
+	}
+	changes := make(map[types.UnlockHash]*balanceChange)
+	change := func(uh types.UnlockHash) *balanceChange {
+		if changes[uh] == nil {
+			changes[uh] = new(balanceChange)
+		}
+		return changes[uh]
+	}
+	for _, scod := range diffs.SiacoinOutputDiffs {
+		c := change(scod.SiacoinOutput.UnlockHash)
+		if scod.Direction == modules.DiffApply {
+			c.scAdded = c.scAdded.Add(scod.SiacoinOutput.Value)
+		} else {
+			c.scRemoved = c.scRemoved.Add(scod.SiacoinOutput.Value)
+		}
+	}
+	for _, sfod := range diffs.SiafundOutputDiffs {
+		c := change(sfod.SiafundOutput.UnlockHash)
+		if sfod.Direction == modules.DiffApply {
+			c.sfAdded = c.sfAdded.Add(sfod.SiafundOutput.Value)
+		} else {
+			c.sfRemoved = c.sfRemoved.Add(sfod.SiafundOutput.Value)
+		}
+	}
+
+	for uh, c := range changes {
+		b, err := tx.Bucket(bucketBalanceHistory).CreateBucketIfNotExists(encoding.Marshal(uh))
+		assertNil(err)
+		var prev modules.BalanceSnapshot
+		if _, v := b.Cursor().Last(); v != nil {
+			assertNil(encoding.Unmarshal(v, &prev))
+		}
+		snapshot := modules.BalanceSnapshot{
+			Height:         height,
+			BlockID:        bid,
+			SiacoinBalance: prev.SiacoinBalance.Add(c.scAdded).Sub(c.scRemoved),
+			SiafundBalance: prev.SiafundBalance.Add(c.sfAdded).Sub(c.sfRemoved),
+		}
+		assertNil(b.Put(balanceHistoryKey(height), encoding.Marshal(snapshot)))
+	}
+}
+func dbRemoveBalanceSnapshots(tx *bolt.Tx, height types.BlockHeight, diffs modules.ConsensusChangeDiffs) {
+	remove := func(uh types.UnlockHash) {
+		bucket := tx.Bucket(bucketBalanceHistory).Bucket(encoding.Marshal(uh))
+		if bucket == nil {
+			return
+		}
+		assertNil(bucket.Delete(balanceHistoryKey(height)))
+		if bucketIsEmpty(bucket) {
+			assertNil(tx.Bucket(bucketBalanceHistory).DeleteBucket(encoding.Marshal(uh)))
+		}
+	}
+	for _, scod := range diffs.SiacoinOutputDiffs {
+		remove(scod.SiacoinOutput.UnlockHash)
+	}
+	for _, sfod := range diffs.SiafundOutputDiffs {
+		remove(sfod.SiafundOutput.UnlockHash)
+	}
+}
+
 // Add/Remove txid from siafund output ID bucket
 func dbAddSiafundOutputID(tx *bolt.Tx, id types.SiafundOutputID, txid types.TransactionID) {
 	b, err := tx.Bucket(bucketSiafundOutputIDs).CreateBucketIfNotExists(encoding.Marshal(id))
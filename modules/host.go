@@ -464,6 +464,10 @@ type (
 		// operation will be completed, meaning that data will be lost.
 		RemoveStorageFolder(index uint16, force bool) error
 
+		// RecheckStorageFolders checks whether any unavailable storage folders
+		// have become available again, loading their sectors if so.
+		RecheckStorageFolders() error
+
 		// ResetStorageFolderHealth will reset the health statistics on a
 		// storage folder.
 		ResetStorageFolderHealth(index uint16) error
@@ -482,6 +486,10 @@ type (
 		// SetInternalSettings sets the hosting parameters of the host.
 		SetInternalSettings(HostInternalSettings) error
 
+		// SetSyncInterval changes how often pending changes are committed
+		// to disk.
+		SetSyncInterval(interval time.Duration) error
+
 		// StorageObligation returns the storage obligation matching the id or
 		// an error if it does not exist
 		StorageObligation(obligationID types.FileContractID) (StorageObligation, error)
@@ -77,6 +77,17 @@ const (
 	storageFolderGranularity = 64
 )
 
+const (
+	// defaultSyncInterval is the amount of time that threadedSyncLoop waits
+	// between commits of the WAL unless changed with SetSyncInterval.
+	defaultSyncInterval = 500 * time.Millisecond
+
+	// minSyncInterval and maxSyncInterval bound the values accepted by
+	// SetSyncInterval.
+	minSyncInterval = 10 * time.Millisecond
+	maxSyncInterval = time.Minute
+)
+
 var (
 	// settingsMetadata is the header that is used when writing the contract
 	// manager's settings to disk.
@@ -41,6 +41,15 @@ import (
 // renters, including storing the data, submitting storage proofs, and deleting
 // the data when a contract is complete.
 type ContractManager struct {
+	// atomicSyncInterval is the time.Duration that threadedSyncLoop waits
+	// between commits of the WAL. It is kept first in the struct to guarantee
+	// 64-bit alignment.
+	atomicSyncInterval int64
+
+	// syncIntervalChanged wakes threadedSyncLoop when the sync interval is
+	// changed so that the new interval is used immediately.
+	syncIntervalChanged chan struct{}
+
 	// The contract manager controls many resources which are spread across
 	// multiple files yet must all be consistent and durable. ACID properties
 	// have been achieved by using a write-ahead-logger (WAL). The in-memory
@@ -132,6 +141,9 @@ func (cm *ContractManager) Close() error {
 // the provided dependencies.
 func newContractManager(dependencies modules.Dependencies, persistDir string) (_ *ContractManager, err error) {
 	cm := &ContractManager{
+		atomicSyncInterval:  int64(defaultSyncInterval),
+		syncIntervalChanged: make(chan struct{}, 1),
+
 		storageFolders:  make(map[uint16]*storageFolder),
 		sectorLocations: make(map[sectorID]sectorLocation),
 
@@ -2,10 +2,15 @@ package contractmanager
 
 import (
 	"math"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
+	"time"
 
 	"gitlab.com/NebulousLabs/fastrand"
+
+	"go.sia.tech/siad/modules"
 )
 
 // BenchmarkSectorLocations explores the cost of creating the sectorLocations
@@ -78,3 +83,37 @@ func BenchmarkStorageFolders(b *testing.B) {
 		randFreeSector(usage)
 	}
 }
+
+// BenchmarkAddSectorSyncInterval measures how the WAL sync interval affects
+// the throughput of sequential sector additions, each of which blocks until
+// the WAL has been committed.
+func BenchmarkAddSectorSyncInterval(b *testing.B) {
+	for _, interval := range []time.Duration{100 * time.Millisecond, defaultSyncInterval} {
+		b.Run(interval.String(), func(b *testing.B) {
+			cmt, err := newContractManagerTester(b.Name())
+			if err != nil {
+				b.Fatal(err)
+			}
+			defer cmt.panicClose()
+			if err := cmt.cm.SetSyncInterval(interval); err != nil {
+				b.Fatal(err)
+			}
+			storageFolderDir := filepath.Join(cmt.persistDir, "storageFolderOne")
+			if err := os.MkdirAll(storageFolderDir, 0700); err != nil {
+				b.Fatal(err)
+			}
+			err = cmt.cm.AddStorageFolder(storageFolderDir, modules.SectorSize*MaximumSectorsPerStorageFolder)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				root, data := randSector()
+				if err := cmt.cm.AddSector(root, data); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
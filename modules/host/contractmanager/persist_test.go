@@ -796,3 +796,76 @@ func TestFolderRechecker(t *testing.T) {
 		t.Error("the storage folder growth does not seem to have worked")
 	}
 }
+
+// TestRecheckStorageFolders checks that a storage folder which was missing at
+// startup can be recovered by an explicit recheck.
+func TestRecheckStorageFolders(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	t.Parallel()
+	cmt, err := newContractManagerTester(t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cmt.panicClose()
+
+	// Add a storage folder containing a sector.
+	storageFolderDir := filepath.Join(cmt.persistDir, "storageFolderOne")
+	err = os.MkdirAll(storageFolderDir, 0700)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = cmt.cm.AddStorageFolder(storageFolderDir, modules.SectorSize*storageFolderGranularity*2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, data := randSector()
+	err = cmt.cm.AddSector(root, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Hide the storage folder and reload the contract manager without the
+	// recheck loop.
+	err = cmt.cm.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Rename(storageFolderDir, storageFolderDir+"-moved")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmt.cm, err = newContractManager(new(dependencyNoRecheck), filepath.Join(cmt.persistDir, modules.ContractManagerDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cmt.cm.ReadSector(root); err == nil {
+		t.Fatal("expected error when reading sector from missing storage folder")
+	}
+
+	// Rechecking while the folder is still missing should change nothing.
+	if err := cmt.cm.RecheckStorageFolders(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cmt.cm.ReadSector(root); err == nil {
+		t.Fatal("expected error when reading sector from missing storage folder")
+	}
+
+	// Restore the storage folder and recheck. The sector should be readable
+	// again.
+	err = os.Rename(storageFolderDir+"-moved", storageFolderDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cmt.cm.RecheckStorageFolders(); err != nil {
+		t.Fatal(err)
+	}
+	readData, err := cmt.cm.ReadSector(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(readData, data) {
+		t.Fatal("sector data mismatch after recheck")
+	}
+}
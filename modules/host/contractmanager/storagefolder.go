@@ -295,6 +295,44 @@ func (cm *ContractManager) availableStorageFolders() []*storageFolder {
 	return sfs
 }
 
+// managedRecheckStorageFolders tries to reopen the files of all unavailable
+// storage folders, loading the sectors of any folder that has been mounted or
+// restored by the user.
+func (cm *ContractManager) managedRecheckStorageFolders() {
+	cm.wal.mu.Lock()
+	cm.sectorMu.Lock()
+	var unavailable []*storageFolder
+	for _, sf := range cm.storageFolders {
+		if atomic.LoadUint64(&sf.atomicUnavailable) == 1 {
+			unavailable = append(unavailable, sf)
+		}
+	}
+	cm.sectorMu.Unlock()
+
+	for _, sf := range unavailable {
+		var err1, err2 error
+		sf.metadataFile, err1 = cm.dependencies.OpenFile(filepath.Join(sf.path, metadataFile), os.O_RDWR, 0700)
+		sf.sectorFile, err2 = cm.dependencies.OpenFile(filepath.Join(sf.path, sectorFile), os.O_RDWR, 0700)
+		if err1 == nil && err2 == nil {
+			// The storage folder has been found, and loading can be
+			// completed.
+			cm.sectorMu.Lock()
+			cm.loadSectorLocations(sf)
+			cm.sectorMu.Unlock()
+		} else {
+			// One of the opens failed, close the file handle for the
+			// opens that did not fail.
+			if err1 == nil {
+				sf.metadataFile.Close()
+			}
+			if err2 == nil {
+				sf.sectorFile.Close()
+			}
+		}
+	}
+	cm.wal.mu.Unlock()
+}
+
 // threadedFolderRecheck checks the unavailable storage folders and looks to see
 // if they have been mounted or restored by the user.
 func (cm *ContractManager) threadedFolderRecheck() {
@@ -314,38 +352,7 @@ func (cm *ContractManager) threadedFolderRecheck() {
 
 		// Check all of the storage folders and recover any that have been added
 		// to the contract manager.
-		cm.wal.mu.Lock()
-		cm.sectorMu.Lock()
-		var unavailable []*storageFolder
-		for _, sf := range cm.storageFolders {
-			if atomic.LoadUint64(&sf.atomicUnavailable) == 1 {
-				unavailable = append(unavailable, sf)
-			}
-		}
-		cm.sectorMu.Unlock()
-
-		for _, sf := range unavailable {
-			var err1, err2 error
-			sf.metadataFile, err1 = cm.dependencies.OpenFile(filepath.Join(sf.path, metadataFile), os.O_RDWR, 0700)
-			sf.sectorFile, err2 = cm.dependencies.OpenFile(filepath.Join(sf.path, sectorFile), os.O_RDWR, 0700)
-			if err1 == nil && err2 == nil {
-				// The storage folder has been found, and loading can be
-				// completed.
-				cm.sectorMu.Lock()
-				cm.loadSectorLocations(sf)
-				cm.sectorMu.Unlock()
-			} else {
-				// One of the opens failed, close the file handle for the
-				// opens that did not fail.
-				if err1 == nil {
-					sf.metadataFile.Close()
-				}
-				if err2 == nil {
-					sf.sectorFile.Close()
-				}
-			}
-		}
-		cm.wal.mu.Unlock()
+		cm.managedRecheckStorageFolders()
 
 		// Increase the sleep time.
 		if sleepTime*2 < maxFolderRecheckInterval {
@@ -354,6 +361,19 @@ func (cm *ContractManager) threadedFolderRecheck() {
 	}
 }
 
+// RecheckStorageFolders immediately checks whether any unavailable storage
+// folders have become available again, rather than waiting for the recheck
+// loop.
+func (cm *ContractManager) RecheckStorageFolders() error {
+	err := cm.tg.Add()
+	if err != nil {
+		return err
+	}
+	defer cm.tg.Done()
+	cm.managedRecheckStorageFolders()
+	return nil
+}
+
 // ResetStorageFolderHealth will reset the read and write statistics for the
 // input storage folder.
 func (cm *ContractManager) ResetStorageFolderHealth(index uint16) error {
@@ -3,6 +3,7 @@ package contractmanager
 import (
 	"encoding/hex"
 	"fmt"
+	"math/bits"
 	"sync"
 	"sync/atomic"
 
@@ -194,6 +195,20 @@ func (wal *writeAheadLog) managedEmptyStorageFolder(sfIndex uint16, startingPoin
 	var errCount, movedCount uint64
 	totalSectors := (64 * uint64(len(sf.usage))) - uint64(startingPoint)
 
+	// Establish the progress fields for the empty operation in the storage
+	// folder. Progress is counted in bytes of sectors that need to be moved.
+	var occupied uint64
+	for _, usage := range sf.usage[startingPoint/storageFolderGranularity:] {
+		occupied += uint64(bits.OnesCount64(usage))
+	}
+	atomic.StoreUint64(&sf.atomicProgressNumerator, 0)
+	atomic.StoreUint64(&sf.atomicProgressDenominator, occupied*modules.SectorSize)
+	defer func() {
+		// Set the progress back to '0'.
+		atomic.StoreUint64(&sf.atomicProgressNumerator, 0)
+		atomic.StoreUint64(&sf.atomicProgressDenominator, 0)
+	}()
+
 	// create a unique alert ID per empty and unregister it after completion.
 	alertID := modules.AlertID("cm-empty-folder-" + hex.EncodeToString(fastrand.Bytes(12)))
 	defer wal.cm.staticAlerter.UnregisterAlert(alertID)
@@ -220,6 +235,7 @@ func (wal *writeAheadLog) managedEmptyStorageFolder(sfIndex uint16, startingPoin
 					} else {
 						atomic.AddUint64(&movedCount, 1)
 					}
+					atomic.AddUint64(&sf.atomicProgressNumerator, modules.SectorSize)
 
 					wal.cm.staticAlerter.RegisterAlert(alertID,
 						fmt.Sprintf("Migrating %d sectors from %s: %d migrated, %d errored",
@@ -258,6 +274,7 @@ func (wal *writeAheadLog) managedEmptyStorageFolder(sfIndex uint16, startingPoin
 				if !exists {
 					// The sector has been deleted, but the usage has not been
 					// updated yet. Safe to ignore.
+					atomic.AddUint64(&sf.atomicProgressNumerator, modules.SectorSize)
 					continue
 				}
 
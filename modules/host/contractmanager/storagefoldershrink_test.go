@@ -7,6 +7,7 @@ import (
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"go.sia.tech/siad/crypto"
 	"go.sia.tech/siad/modules"
@@ -1290,3 +1291,101 @@ func TestShrinkSingleStorageFolder(t *testing.T) {
 		t.Errorf("Could not find all %v sectors: %v\n", len(roots), misses)
 	}
 }
+
+// TestShrinkStorageFolderProgress checks that a storage folder reports
+// progress while sectors are moved out of it during a shrink.
+func TestShrinkStorageFolderProgress(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	t.Parallel()
+	cmt, err := newContractManagerTester(t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cmt.panicClose()
+
+	// Add two storage folders, the second one having room for the sectors
+	// that get displaced from the first.
+	storageFolderOne := filepath.Join(cmt.persistDir, "storageFolderOne")
+	storageFolderTwo := filepath.Join(cmt.persistDir, "storageFolderTwo")
+	for _, dir := range []string{storageFolderOne, storageFolderTwo} {
+		err = os.MkdirAll(dir, 0700)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = cmt.cm.AddStorageFolder(storageFolderOne, modules.SectorSize*storageFolderGranularity*8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sfs := cmt.cm.StorageFolders()
+	if len(sfs) != 1 {
+		t.Fatal("there should only be one storage folder")
+	}
+	sfIndex := sfs[0].Index
+
+	// Fill part of the first storage folder with sectors.
+	var wg sync.WaitGroup
+	for i := 0; i < storageFolderGranularity*3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			root, data := randSector()
+			if err := cmt.cm.AddSector(root, data); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	err = cmt.cm.AddStorageFolder(storageFolderTwo, modules.SectorSize*storageFolderGranularity*3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Determine how many bytes of sectors will need to be moved.
+	cmt.cm.sectorMu.Lock()
+	var displaced uint64
+	for _, usage := range cmt.cm.storageFolders[sfIndex].usage[2:] {
+		for ; usage != 0; usage &= usage - 1 {
+			displaced += modules.SectorSize
+		}
+	}
+	cmt.cm.sectorMu.Unlock()
+
+	// Shrink the storage folder while polling its progress.
+	done := make(chan error)
+	go func() {
+		done <- cmt.cm.ResizeStorageFolder(sfIndex, modules.SectorSize*storageFolderGranularity*2, false)
+	}()
+	var last uint64
+	for polling := true; polling; {
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Fatal(err)
+			}
+			polling = false
+		case <-time.After(time.Millisecond):
+		}
+		for _, sf := range cmt.cm.StorageFolders() {
+			if sf.Index != sfIndex || sf.ProgressDenominator == 0 {
+				continue
+			}
+			if sf.ProgressDenominator != displaced {
+				t.Fatalf("expected %v bytes to be moved, progress reports %v", displaced, sf.ProgressDenominator)
+			}
+			if sf.ProgressNumerator < last || sf.ProgressNumerator > sf.ProgressDenominator {
+				t.Fatalf("invalid progress %v/%v after %v", sf.ProgressNumerator, sf.ProgressDenominator, last)
+			}
+			last = sf.ProgressNumerator
+		}
+	}
+
+	// Once the shrink has completed the progress should be cleared.
+	for _, sf := range cmt.cm.StorageFolders() {
+		if sf.ProgressNumerator != 0 || sf.ProgressDenominator != 0 {
+			t.Error("storage folder is still reporting progress", sf.ProgressNumerator, sf.ProgressDenominator)
+		}
+	}
+}
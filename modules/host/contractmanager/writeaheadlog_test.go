@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"go.sia.tech/siad/build"
 	"go.sia.tech/siad/modules"
@@ -48,3 +49,80 @@ func TestLoadWAL(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+// TestSetSyncInterval checks that SetSyncInterval enforces its bounds and that
+// the sync loop picks up a new interval without waiting out the old one.
+func TestSetSyncInterval(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	t.Parallel()
+	cmt, err := newContractManagerTester(t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cmt.panicClose()
+
+	if err := cmt.cm.SetSyncInterval(minSyncInterval - 1); err == nil {
+		t.Fatal("expected an error for an interval below the minimum")
+	}
+	if err := cmt.cm.SetSyncInterval(maxSyncInterval + 1); err == nil {
+		t.Fatal("expected an error for an interval above the maximum")
+	}
+
+	storageFolderDir := filepath.Join(cmt.persistDir, "storageFolderOne")
+	err = os.MkdirAll(storageFolderDir, 0700)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = cmt.cm.AddStorageFolder(storageFolderDir, modules.SectorSize*64)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// AddSector blocks until the WAL has been committed. With the maximum
+	// interval that takes a minute, so the sector should only be added
+	// promptly if shortening the interval wakes the sync loop.
+	if err := cmt.cm.SetSyncInterval(maxSyncInterval); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(2 * defaultSyncInterval)
+	errChan := make(chan error, 1)
+	go func() {
+		root, data := randSector()
+		errChan <- cmt.cm.AddSector(root, data)
+	}()
+	if err := cmt.cm.SetSyncInterval(minSyncInterval); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-errChan:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(maxSyncInterval / 2):
+		t.Error("sync loop did not pick up the shorter interval")
+		// Wait for the sector to be added before closing the contract
+		// manager.
+		<-errChan
+	}
+}
+
+// TestSetSyncIntervalAfterClose checks that SetSyncInterval fails once the
+// contract manager has been closed.
+func TestSetSyncIntervalAfterClose(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	t.Parallel()
+	cmt, err := newContractManagerTester(t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cmt.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmt.cm.SetSyncInterval(defaultSyncInterval); err == nil {
+		t.Fatal("expected an error setting the sync interval after close")
+	}
+}
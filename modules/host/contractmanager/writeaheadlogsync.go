@@ -2,6 +2,7 @@ package contractmanager
 
 import (
 	"encoding/json"
+	"fmt"
 	"path/filepath"
 	"sync"
 	"sync/atomic"
@@ -298,12 +299,15 @@ func (wal *writeAheadLog) threadedSyncLoop(threadsStopped chan struct{}, syncLoo
 		return
 	}
 
-	syncInterval := 500 * time.Millisecond
 	for {
+		syncInterval := time.Duration(atomic.LoadInt64(&wal.cm.atomicSyncInterval))
 		select {
 		case <-threadsStopped:
 			close(syncLoopStopped)
 			return
+		case <-wal.cm.syncIntervalChanged:
+			// Start waiting again using the new interval.
+			continue
 		case <-time.After(syncInterval):
 			// Commit all of the changes in the WAL to disk, and then apply the
 			// changes.
@@ -313,3 +317,25 @@ func (wal *writeAheadLog) threadedSyncLoop(threadsStopped chan struct{}, syncLoo
 		}
 	}
 }
+
+// SetSyncInterval changes how often the WAL is committed to disk. Shorter
+// intervals reduce the latency of operations that wait for a commit at the
+// cost of more frequent disk syncs. The new interval takes effect
+// immediately, timed from the moment it is set.
+func (cm *ContractManager) SetSyncInterval(interval time.Duration) error {
+	err := cm.tg.Add()
+	if err != nil {
+		return err
+	}
+	defer cm.tg.Done()
+
+	if interval < minSyncInterval || interval > maxSyncInterval {
+		return fmt.Errorf("sync interval must be between %v and %v", minSyncInterval, maxSyncInterval)
+	}
+	atomic.StoreInt64(&cm.atomicSyncInterval, int64(interval))
+	select {
+	case cm.syncIntervalChanged <- struct{}{}:
+	default:
+	}
+	return nil
+}
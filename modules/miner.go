@@ -40,6 +40,14 @@ type CPUMiner interface {
 	// Mining returns true if the cpu miner is enabled, and false otherwise.
 	CPUMining() bool
 
+	// CPUThrottle returns the fraction of a cpu core that the cpu miner is
+	// allowed to use.
+	CPUThrottle() float64
+
+	// SetCPUThrottle limits the cpu miner to roughly the given fraction of a
+	// cpu core. The fraction must be within (0, 1].
+	SetCPUThrottle(fraction float64) error
+
 	// StartMining turns on the miner, which will endlessly work for new
 	// blocks.
 	StartCPUMining()
@@ -80,5 +88,11 @@ type TestMiner interface {
 type Miner interface {
 	BlockManager
 	CPUMiner
+
+	// SetPayoutAddress sets the address that receives the rewards of mined
+	// blocks, overriding the addresses provided by the wallet. Setting the
+	// empty address returns to using the wallet.
+	SetPayoutAddress(types.UnlockHash) error
+
 	io.Closer
 }
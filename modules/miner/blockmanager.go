@@ -153,9 +153,13 @@ func (m *Miner) managedSubmitBlock(b types.Block) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	// Grab a new address for the miner. Call may fail if the wallet is locked
-	// or if the wallet addresses have been exhausted.
+	// Grab a new address for the miner, unless the user has specified one.
+	// Call may fail if the wallet is locked or if the wallet addresses have
+	// been exhausted.
 	m.persist.BlocksFound = append(m.persist.BlocksFound, b.ID())
+	if m.persist.PayoutAddress != (types.UnlockHash{}) {
+		return m.saveSync()
+	}
 	var uc types.UnlockConditions
 	uc, err = m.wallet.NextAddress()
 	if err != nil {
@@ -6,6 +6,15 @@ import (
 	"go.sia.tech/siad/build"
 )
 
+// throttlePause returns how long the cpu miner should pause after working for
+// elapsed so that it only uses the given fraction of a cpu core.
+func throttlePause(elapsed time.Duration, fraction float64) time.Duration {
+	if fraction <= 0 || fraction >= 1 {
+		return 0
+	}
+	return time.Duration(float64(elapsed) * (1 - fraction) / fraction)
+}
+
 // threadedMine starts a gothread that does CPU mining. threadedMine is the
 // only function that should be setting the mining flag to true.
 func (m *Miner) threadedMine() {
@@ -49,10 +58,13 @@ func (m *Miner) threadedMine() {
 		// Prepare the work and release the miner lock.
 		bfw := m.blockForWork()
 		target := m.persist.Target
+		throttle := m.persist.CPUThrottle
 		m.mu.Unlock()
 
 		// Solve the block.
+		solveStart := time.Now()
 		b, solved := solveBlock(bfw, target)
+		solveTime := time.Since(solveStart)
 		if solved {
 			err := m.managedSubmitBlock(b)
 			if err != nil {
@@ -69,6 +81,15 @@ func (m *Miner) threadedMine() {
 			m.hashRate = 1e9 * solveAttempts / nanosecondsElapsed
 		}
 		m.mu.Unlock()
+
+		// Rest for a while if the miner is throttled. The pause is included
+		// in the next hashrate measurement.
+		if pause := throttlePause(solveTime, throttle); pause > 0 {
+			select {
+			case <-m.tg.StopChan():
+			case <-time.After(pause):
+			}
+		}
 	}
 }
 
@@ -84,6 +105,22 @@ func (m *Miner) CPUHashrate() int {
 	return int(m.hashRate)
 }
 
+// CPUThrottle returns the fraction of a cpu core that the cpu miner is allowed
+// to use.
+func (m *Miner) CPUThrottle() float64 {
+	if err := m.tg.Add(); err != nil {
+		build.Critical(err)
+	}
+	defer m.tg.Done()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.persist.CPUThrottle == 0 {
+		return 1
+	}
+	return m.persist.CPUThrottle
+}
+
 // CPUMining indicates whether the cpu miner is running.
 func (m *Miner) CPUMining() bool {
 	if err := m.tg.Add(); err != nil {
@@ -123,3 +160,21 @@ func (m *Miner) StopCPUMining() {
 	m.hashRate = 0
 	m.miningOn = false
 }
+
+// SetCPUThrottle limits the cpu miner to roughly the given fraction of a cpu
+// core by pausing between rounds of hashing. A fraction of 1 removes the
+// limit.
+func (m *Miner) SetCPUThrottle(fraction float64) error {
+	if fraction <= 0 || fraction > 1 {
+		return errInvalidThrottle
+	}
+	if err := m.tg.Add(); err != nil {
+		return err
+	}
+	defer m.tg.Done()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.persist.CPUThrottle = fraction
+	return m.saveSync()
+}
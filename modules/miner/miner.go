@@ -29,6 +29,10 @@ var (
 	errNilTpool  = errors.New("miner cannot use a nil transaction pool")
 	errNilWallet = errors.New("miner cannot use a nil wallet")
 
+	// errInvalidThrottle is returned by SetCPUThrottle if the fraction is not
+	// within (0, 1].
+	errInvalidThrottle = errors.New("cpu throttle must be greater than 0 and at most 1")
+
 	// HeaderMemory is the number of previous calls to 'header'
 	// that are remembered. Additionally, 'header' will only poll for a
 	// new block every 'headerMemory / blockMemory' times it is
@@ -237,8 +241,13 @@ func (m *Miner) Close() error {
 }
 
 // checkAddress checks that the miner has an address, fetching an address from
-// the wallet if not.
+// the wallet if not. A user-specified payout address takes precedence over
+// the wallet.
 func (m *Miner) checkAddress() error {
+	if m.persist.PayoutAddress != (types.UnlockHash{}) {
+		m.persist.Address = m.persist.PayoutAddress
+		return nil
+	}
 	addrs, err := m.wallet.AllAddresses()
 	if err != nil {
 		return err
@@ -261,6 +270,31 @@ func (m *Miner) checkAddress() error {
 	return nil
 }
 
+// SetPayoutAddress sets the address that receives the rewards of blocks mined
+// from now on. Setting the empty address returns to using addresses provided
+// by the wallet, and fails if the wallet cannot provide an address.
+func (m *Miner) SetPayoutAddress(addr types.UnlockHash) error {
+	if err := m.tg.Add(); err != nil {
+		return err
+	}
+	defer m.tg.Done()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// checkAddress adopts a non-empty payout address, and otherwise falls back
+	// to an address from the wallet instead of the cleared payout address.
+	m.persist.PayoutAddress = addr
+	if err := m.checkAddress(); err != nil {
+		return err
+	}
+
+	// Refresh the source block so that new headers pay out to the new
+	// address.
+	m.newSourceBlock()
+	return m.saveSync()
+}
+
 // BlocksMined returns the number of good blocks and stale blocks that have
 // been mined by the miner.
 func (m *Miner) BlocksMined() (goodBlocks, staleBlocks int) {
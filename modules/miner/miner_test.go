@@ -354,3 +354,181 @@ func TestMinerCloseDeadlock(t *testing.T) {
 		t.Fatal("mt.miner.Close never completed")
 	}
 }
+
+// TestThrottlePause checks that throttlePause computes pauses which limit the
+// cpu miner to the requested fraction of its time.
+func TestThrottlePause(t *testing.T) {
+	tests := []struct {
+		fraction float64
+		pause    time.Duration
+	}{
+		{1, 0},
+		{0, 0},
+		{0.5, time.Second},
+		{0.25, 3 * time.Second},
+		{0.75, time.Second / 3},
+	}
+	for _, test := range tests {
+		if pause := throttlePause(time.Second, test.fraction); pause != test.pause {
+			t.Errorf("throttle %v: expected pause %v, got %v", test.fraction, test.pause, pause)
+		}
+	}
+}
+
+// TestCPUThrottle checks that the cpu throttle can be set, is persisted, and
+// that a throttled cpu miner still finds blocks.
+func TestCPUThrottle(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	mt, err := createMinerTester(t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The miner should start out unthrottled and reject invalid fractions.
+	if throttle := mt.miner.CPUThrottle(); throttle != 1 {
+		t.Fatal("expected the miner to be unthrottled, got", throttle)
+	}
+	for _, fraction := range []float64{0, -0.5, 1.5} {
+		if err := mt.miner.SetCPUThrottle(fraction); !errors.Contains(err, errInvalidThrottle) {
+			t.Errorf("expected %v for throttle %v, got %v", errInvalidThrottle, fraction, err)
+		}
+	}
+	if err := mt.miner.SetCPUThrottle(0.5); err != nil {
+		t.Fatal(err)
+	}
+
+	// A throttled miner should still make progress.
+	height := mt.cs.Height()
+	mt.miner.StartCPUMining()
+	err = build.Retry(100, 100*time.Millisecond, func() error {
+		if mt.cs.Height() <= height {
+			return errors.New("cpu miner has not found a block")
+		}
+		return nil
+	})
+	mt.miner.StopCPUMining()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The throttle should survive a restart.
+	if err := mt.miner.Close(); err != nil {
+		t.Fatal(err)
+	}
+	m, err := New(mt.cs, mt.tpool, mt.wallet, filepath.Join(mt.persistDir, modules.MinerDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := m.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	if throttle := m.CPUThrottle(); throttle != 0.5 {
+		t.Fatal("expected the throttle to be persisted, got", throttle)
+	}
+}
+
+// TestEstimateNetworkHashRate checks the network hashrate estimate at known
+// difficulties.
+func TestEstimateNetworkHashRate(t *testing.T) {
+	tests := []struct {
+		difficulty types.Currency
+		blockTime  time.Duration
+		hashRate   float64
+	}{
+		{types.NewCurrency64(600), 10 * time.Minute, 1},
+		{types.NewCurrency64(6e12), 10 * time.Minute, 1e10},
+		{types.NewCurrency64(1000), 2 * time.Second, 500},
+		{types.ZeroCurrency, 10 * time.Minute, 0},
+		{types.NewCurrency64(600), 0, 0},
+	}
+	for _, test := range tests {
+		if hr := EstimateNetworkHashRate(test.difficulty, test.blockTime); hr != test.hashRate {
+			t.Errorf("difficulty %v over %v: expected %v, got %v", test.difficulty, test.blockTime, test.hashRate, hr)
+		}
+	}
+}
+
+// TestIntegrationSetPayoutAddress checks that blocks pay out to a payout
+// address set by the user, and that clearing the payout address returns to
+// paying out to addresses from the wallet.
+func TestIntegrationSetPayoutAddress(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	mt, err := createMinerTester(t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Set a payout address that is not owned by the wallet.
+	payout := types.UnlockHash{1, 2, 3}
+	if err := mt.miner.SetPayoutAddress(payout); err != nil {
+		t.Fatal(err)
+	}
+	mt.miner.mu.Lock()
+	sourcePayout := mt.miner.sourceBlock.MinerPayouts[0].UnlockHash
+	mt.miner.mu.Unlock()
+	if sourcePayout != payout {
+		t.Fatal("source block does not pay out to the payout address")
+	}
+	b, err := mt.miner.AddBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.MinerPayouts[0].UnlockHash != payout {
+		t.Fatal("block does not pay out to the payout address")
+	}
+
+	// Clearing the payout address while the wallet is locked should fail
+	// rather than leave the miner paying out to the empty address.
+	if err := mt.wallet.Lock(); err != nil {
+		t.Fatal(err)
+	}
+	if err := mt.miner.SetPayoutAddress(types.UnlockHash{}); err == nil {
+		t.Fatal("expected an error when no wallet address is available")
+	}
+	mt.miner.mu.Lock()
+	sourcePayout = mt.miner.sourceBlock.MinerPayouts[0].UnlockHash
+	mt.miner.mu.Unlock()
+	if sourcePayout == (types.UnlockHash{}) {
+		t.Fatal("source block pays out to the empty address")
+	}
+	if err := mt.wallet.Unlock(mt.walletKey); err != nil {
+		t.Fatal(err)
+	}
+
+	// Clear the payout address. The next block should pay out to an address
+	// owned by the wallet.
+	if err := mt.miner.SetPayoutAddress(types.UnlockHash{}); err != nil {
+		t.Fatal(err)
+	}
+	mt.miner.mu.Lock()
+	sourcePayout = mt.miner.sourceBlock.MinerPayouts[0].UnlockHash
+	mt.miner.mu.Unlock()
+	b, err = mt.miner.AddBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sourcePayout != b.MinerPayouts[0].UnlockHash {
+		t.Fatal("source block and next block pay out to different addresses")
+	}
+	uh := b.MinerPayouts[0].UnlockHash
+	if uh == payout || uh == (types.UnlockHash{}) {
+		t.Fatal("block pays out to", uh)
+	}
+	addrs, err := mt.wallet.AllAddresses()
+	if err != nil {
+		t.Fatal(err)
+	}
+	owned := false
+	for _, addr := range addrs {
+		owned = owned || addr == uh
+	}
+	if !owned {
+		t.Fatal("block does not pay out to a wallet address")
+	}
+}
@@ -32,6 +32,14 @@ type (
 		Address       types.UnlockHash
 		BlocksFound   []types.BlockID
 		UnsolvedBlock types.Block
+
+		// PayoutAddress is a user-specified address that receives the block
+		// rewards instead of the addresses provided by the wallet.
+		PayoutAddress types.UnlockHash
+
+		// CPUThrottle is the fraction of a cpu core that the cpu miner is
+		// allowed to use. Zero means that the cpu miner is not throttled.
+		CPUThrottle float64
 	}
 )
 
@@ -0,0 +1,18 @@
+package miner
+
+import (
+	"time"
+
+	"go.sia.tech/siad/types"
+)
+
+// EstimateNetworkHashRate estimates the hashrate of the whole network in
+// hashes per second. The difficulty is the expected number of hashes required
+// to find a block, and the network finds a block every blockTime on average.
+func EstimateNetworkHashRate(difficulty types.Currency, blockTime time.Duration) float64 {
+	if blockTime <= 0 {
+		return 0
+	}
+	hashes, _ := difficulty.Float64()
+	return hashes / blockTime.Seconds()
+}
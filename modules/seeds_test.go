@@ -36,3 +36,54 @@ func TestEphemeralRenterSeed(t *testing.T) {
 		}
 	}
 }
+
+// TestVerifySeedEntropy tests that weak seeds are rejected by
+// VerifySeedEntropy but can still be parsed by StringToSeed.
+func TestVerifySeedEntropy(t *testing.T) {
+	// All zeros and all ones should be rejected.
+	var zeros, ones Seed
+	for i := range ones {
+		ones[i] = 0xff
+	}
+	for _, seed := range []Seed{zeros, ones} {
+		if err := VerifySeedEntropy(seed); err != ErrWeakSeed {
+			t.Fatal("expected ErrWeakSeed, got", err)
+		}
+		// A weak seed should still be parsed so that existing wallets
+		// created from it remain usable.
+		str, err := SeedToString(seed, "english")
+		if err != nil {
+			t.Fatal(err)
+		}
+		parsed, err := StringToSeed(str, "english")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parsed != seed {
+			t.Fatal("weak seed did not survive a round trip")
+		}
+	}
+
+	// A seed with a short repeating pattern should be rejected.
+	var pattern Seed
+	for i := range pattern {
+		pattern[i] = byte(i % (SeedMinUniqueBytes - 1))
+	}
+	if err := VerifySeedEntropy(pattern); err != ErrWeakSeed {
+		t.Fatal("expected ErrWeakSeed, got", err)
+	}
+
+	// A random seed should be accepted.
+	var seed Seed
+	fastrand.Read(seed[:])
+	if err := VerifySeedEntropy(seed); err != nil {
+		t.Fatal(err)
+	}
+	str, err := SeedToString(seed, "english")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := StringToSeed(str, "english"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1,6 +1,8 @@
 package modules
 
 import (
+	"time"
+
 	"go.sia.tech/siad/crypto"
 )
 
@@ -112,6 +114,10 @@ type (
 		// operation will be completed, meaning that data will be lost.
 		RemoveStorageFolder(index uint16, force bool) error
 
+		// RecheckStorageFolders checks whether any unavailable storage folders
+		// have become available again, loading their sectors if so.
+		RecheckStorageFolders() error
+
 		// ResetStorageFolderHealth will reset the health statistics on a
 		// storage folder.
 		ResetStorageFolderHealth(index uint16) error
@@ -128,6 +134,10 @@ type (
 		// that data will be lost.
 		ResizeStorageFolder(index uint16, newSize uint64, force bool) error
 
+		// SetSyncInterval changes how often pending changes are committed
+		// to disk.
+		SetSyncInterval(interval time.Duration) error
+
 		// StorageFolders will return a list of storage folders tracked by the
 		// manager.
 		StorageFolders() []StorageFolderMetadata
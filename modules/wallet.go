@@ -23,6 +23,11 @@ const (
 	// addresses to prevent accidental spending.
 	SeedChecksumSize = 6
 
+	// SeedMinUniqueBytes is the minimum number of distinct byte values a seed
+	// must contain to be considered random. A uniformly random 32 byte seed
+	// has fewer than 8 distinct bytes with negligible probability.
+	SeedMinUniqueBytes = 8
+
 	// WalletDir is the directory that contains the wallet persistence.
 	WalletDir = "wallet"
 )
@@ -49,6 +54,10 @@ var (
 	// ErrWalletShutdown is returned when a method can't continue execution due
 	// to the wallet shutting down.
 	ErrWalletShutdown = errors.New("wallet is shutting down")
+
+	// ErrWeakSeed is returned if a seed does not contain enough entropy to
+	// safely protect a wallet.
+	ErrWeakSeed = errors.New("seed is not valid: insufficient entropy")
 )
 
 type (
@@ -395,6 +404,11 @@ type (
 		// generated from the seed.
 		PrimarySeed() (Seed, uint64, error)
 
+		// PrimarySeedProgress returns the number of addresses that have been
+		// generated from the primary seed, i.e. the index of the next address
+		// that NextAddress will return.
+		PrimarySeedProgress() (uint64, error)
+
 		// SignTransaction signs txn using secret keys known to the wallet.
 		// The transaction should be complete with the exception of the
 		// Signature fields of each TransactionSignature referenced by toSign.
@@ -607,3 +621,23 @@ func StringToSeed(str string, did mnemonics.DictionaryID) (Seed, error) {
 	}
 	return seed, nil
 }
+
+// VerifySeedEntropy checks that a seed was plausibly generated from a random
+// source. Seeds that consist of very few distinct bytes, such as all zeros or
+// all ones, are rejected since they are trivially guessable. The check is not
+// part of StringToSeed so that wallets created from weak seeds can still be
+// unlocked, restored and swept.
+func VerifySeedEntropy(seed Seed) error {
+	var seen [256]bool
+	unique := 0
+	for _, b := range seed {
+		if !seen[b] {
+			seen[b] = true
+			unique++
+		}
+	}
+	if unique < SeedMinUniqueBytes {
+		return ErrWeakSeed
+	}
+	return nil
+}
@@ -66,6 +66,72 @@ func generateKeys(seed modules.Seed, start, n uint64) []spendableKey {
 	return keys
 }
 
+// SeedUnlockConditions returns the unlock conditions that seed generates at
+// the provided index.
+func SeedUnlockConditions(seed modules.Seed, index uint64) types.UnlockConditions {
+	return generateSpendableKey(seed, index).UnlockConditions
+}
+
+// MaxSeedIndexSearch returns the number of indices worth searching for an
+// address of a seed that has generated progress addresses: every generated
+// address plus the wallet's lookahead.
+func MaxSeedIndexSearch(progress uint64) uint64 {
+	return maxLookahead(progress)
+}
+
+// FindSeedIndex returns the first index below maxIndex at which seed generates
+// the provided address.
+func FindSeedIndex(seed modules.Seed, uh types.UnlockHash, maxIndex uint64) (uint64, bool) {
+	for i := uint64(0); i < maxIndex; i++ {
+		if generateSpendableKey(seed, i).UnlockConditions.UnlockHash() == uh {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// FindSeedIndexParallel is like FindSeedIndex, but splits the search across the
+// provided number of workers. The search is abandoned if cancel is closed.
+func FindSeedIndexParallel(seed modules.Seed, uh types.UnlockHash, maxIndex uint64, workers int, cancel <-chan struct{}) (uint64, bool) {
+	if workers < 1 {
+		workers = 1
+	}
+	// Every worker checks every workers-th index. Once a match is found,
+	// workers stop searching indices above it.
+	var mu sync.Mutex
+	found := maxIndex
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func(offset uint64) {
+			defer wg.Done()
+			for i := offset; i < maxIndex; i += uint64(workers) {
+				select {
+				case <-cancel:
+					return
+				default:
+				}
+				mu.Lock()
+				done := i >= found
+				mu.Unlock()
+				if done {
+					return
+				}
+				if generateSpendableKey(seed, i).UnlockConditions.UnlockHash() == uh {
+					mu.Lock()
+					if i < found {
+						found = i
+					}
+					mu.Unlock()
+					return
+				}
+			}
+		}(uint64(w))
+	}
+	wg.Wait()
+	return found, found < maxIndex
+}
+
 // createSeedFile creates and encrypts a seedFile.
 func createSeedFile(masterKey crypto.CipherKey, seed modules.Seed) seedFile {
 	var sf seedFile
@@ -225,6 +291,22 @@ func (w *Wallet) PrimarySeed() (modules.Seed, uint64, error) {
 	return w.primarySeed, remaining, nil
 }
 
+// PrimarySeedProgress returns the number of addresses that have been generated
+// from the primary seed.
+func (w *Wallet) PrimarySeedProgress() (uint64, error) {
+	if err := w.tg.Add(); err != nil {
+		return 0, modules.ErrWalletShutdown
+	}
+	defer w.tg.Done()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.unlocked {
+		return 0, modules.ErrLockedWallet
+	}
+	return dbGetPrimarySeedProgress(w.dbTx)
+}
+
 // MarkAddressUnused marks the provided address as unused which causes it to be
 // handed out by a subsequent call to `NextAddresses` again.
 func (w *Wallet) MarkAddressUnused(addrs ...types.UnlockConditions) error {
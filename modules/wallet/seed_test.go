@@ -3,9 +3,11 @@ package wallet
 import (
 	"bytes"
 	"path/filepath"
+	"runtime"
 	"testing"
 
 	"gitlab.com/NebulousLabs/errors"
+	"gitlab.com/NebulousLabs/fastrand"
 	"go.sia.tech/siad/build"
 	"go.sia.tech/siad/crypto"
 	"go.sia.tech/siad/modules"
@@ -692,3 +694,35 @@ func TestMarkAddressUnused(t *testing.T) {
 		t.Fatal("wrong number of unused keys")
 	}
 }
+
+// TestFindSeedIndex tests that FindSeedIndex and FindSeedIndexParallel find the
+// index at which a seed generates an address.
+func TestFindSeedIndex(t *testing.T) {
+	var seed modules.Seed
+	fastrand.Read(seed[:])
+	uh := generateSpendableKey(seed, 500).UnlockConditions.UnlockHash()
+
+	if index, ok := FindSeedIndex(seed, uh, 1000); !ok || index != 500 {
+		t.Fatalf("expected to find index 500, got %v %v", index, ok)
+	}
+	for _, workers := range []int{1, 3, runtime.NumCPU()} {
+		if index, ok := FindSeedIndexParallel(seed, uh, 1000, workers, nil); !ok || index != 500 {
+			t.Fatalf("expected %v workers to find index 500, got %v %v", workers, index, ok)
+		}
+	}
+
+	// The address should not be found if the search stops before its index.
+	if _, ok := FindSeedIndex(seed, uh, 500); ok {
+		t.Fatal("found address beyond maxIndex")
+	}
+	if _, ok := FindSeedIndexParallel(seed, uh, 500, 4, nil); ok {
+		t.Fatal("found address beyond maxIndex")
+	}
+
+	// A cancelled search should not find the address.
+	cancel := make(chan struct{})
+	close(cancel)
+	if _, ok := FindSeedIndexParallel(seed, uh, 1000, 4, cancel); ok {
+		t.Fatal("cancelled search found address")
+	}
+}
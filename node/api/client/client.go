@@ -7,7 +7,9 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"go.sia.tech/siad/build"
 	"go.sia.tech/siad/node/api"
@@ -30,6 +32,10 @@ type (
 		// Password must match the password of the siad server.
 		Password string
 
+		// SignRequests causes requests to be authenticated by signing them
+		// with Password instead of sending Password to the server.
+		SignRequests bool
+
 		// UserAgent must match the User-Agent required by the siad server. If not
 		// set, it defaults to "Sia-Agent".
 		UserAgent string
@@ -110,6 +116,16 @@ func DefaultOptions() (Options, error) {
 // NewRequest constructs a request to the siad HTTP API, setting the correct
 // User-Agent and Basic Auth. The resource path must begin with /.
 func (c *Client) NewRequest(method, resource string, body io.Reader) (*http.Request, error) {
+	// Signing a request requires the full body, so read it upfront.
+	var signedBody []byte
+	if c.Password != "" && c.SignRequests && body != nil {
+		var err error
+		signedBody, err = ioutil.ReadAll(body)
+		if err != nil {
+			return nil, errors.AddContext(err, "failed to read request body")
+		}
+		body = bytes.NewReader(signedBody)
+	}
 	url := "http://" + c.Address + resource
 	req, err := http.NewRequest(method, url, body)
 	if err != nil {
@@ -120,7 +136,11 @@ func (c *Client) NewRequest(method, resource string, body io.Reader) (*http.Requ
 		agent = "Sia-Agent"
 	}
 	req.Header.Set("User-Agent", agent)
-	if c.Password != "" {
+	if c.Password != "" && c.SignRequests {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(api.TimestampHeader, timestamp)
+		req.Header.Set(api.SignatureHeader, api.RequestSignature(c.Password, method, req.URL.RequestURI(), timestamp, signedBody))
+	} else if c.Password != "" {
 		req.SetBasicAuth("", c.Password)
 	}
 	return req, nil
@@ -0,0 +1,147 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+
+	"go.sia.tech/siad/node/api"
+	"go.sia.tech/siad/types"
+)
+
+// TestSignRequests checks that a client configured to sign its requests is
+// authenticated by the server without sending its password.
+func TestSignRequests(t *testing.T) {
+	router := httprouter.New()
+	handler := func(w http.ResponseWriter, req *http.Request, _ httprouter.Params) {
+		// The password should never be sent.
+		if _, _, ok := req.BasicAuth(); ok {
+			api.WriteError(w, api.Error{Message: "password was sent"}, http.StatusBadRequest)
+			return
+		}
+		if req.FormValue("foo") != "bar" {
+			api.WriteError(w, api.Error{Message: "form value was not preserved"}, http.StatusBadRequest)
+			return
+		}
+		api.WriteSuccess(w)
+	}
+	router.GET("/test", api.RequirePassword(handler, "password"))
+	router.POST("/test", api.RequirePassword(handler, "password"))
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	c := New(Options{
+		Address:      strings.TrimPrefix(srv.URL, "http://"),
+		Password:     "password",
+		SignRequests: true,
+	})
+	if err := c.get("/test?foo=bar", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.post("/test", "foo=bar", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// Signing with the wrong password should fail.
+	c.Password = "wrong password"
+	if err := c.get("/test?foo=bar", nil); err == nil {
+		t.Fatal("request signed with the wrong password was accepted")
+	}
+}
+
+// TestWaitForBlockHeight checks that WaitForBlockHeight polls until the
+// requested height is reached, and that it stops when its context is done.
+func TestWaitForBlockHeight(t *testing.T) {
+	// Mock a node whose height increases with every poll.
+	var height uint64
+	router := httprouter.New()
+	router.GET("/consensus", func(w http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+		api.WriteJSON(w, api.ConsensusGET{Height: types.BlockHeight(atomic.AddUint64(&height, 1) - 1)})
+	})
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+	c := New(Options{Address: strings.TrimPrefix(srv.URL, "http://")})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	cg, err := c.WaitForBlockHeight(ctx, 5, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cg.Height != 5 {
+		t.Fatal("expected to stop polling at height 5, got", cg.Height)
+	}
+
+	// A cancelled context should stop the polling.
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.WaitForBlockHeight(ctx, 100, time.Millisecond); !errors.Is(err, context.Canceled) {
+		t.Fatal("expected context.Canceled, got", err)
+	}
+
+	// So should an expired deadline.
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := c.WaitForBlockHeight(ctx, math.MaxUint64, time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal("expected context.DeadlineExceeded, got", err)
+	}
+}
+
+// TestWaitForTransaction checks that WaitForTransaction polls until the
+// transaction is confirmed and returns the block that confirmed it.
+func TestWaitForTransaction(t *testing.T) {
+	// Mock a node that confirms the transaction on the third poll, in the
+	// block at height 7 of a 10 block chain.
+	txid := types.TransactionID{1}
+	var polls uint64
+	router := httprouter.New()
+	router.GET("/tpool/status/:id", func(w http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+		n := atomic.AddUint64(&polls, 1)
+		api.WriteJSON(w, api.TpoolStatusGET{InPool: n < 3, Confirmed: n >= 3})
+	})
+	router.GET("/consensus", func(w http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+		api.WriteJSON(w, api.ConsensusGET{Height: 10})
+	})
+	router.GET("/consensus/blocks", func(w http.ResponseWriter, req *http.Request, _ httprouter.Params) {
+		var cbg api.ConsensusBlocksGet
+		fmt.Sscan(req.FormValue("height"), &cbg.Height)
+		cbg.ID = types.BlockID{byte(cbg.Height)}
+		if cbg.Height == 7 {
+			cbg.Transactions = []api.ConsensusBlocksGetTxn{{ID: txid}}
+		}
+		api.WriteJSON(w, cbg)
+	})
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+	c := New(Options{Address: strings.TrimPrefix(srv.URL, "http://")})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	cbg, err := c.WaitForTransaction(ctx, txid, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadUint64(&polls); n != 3 {
+		t.Fatal("expected 3 polls, got", n)
+	}
+	if cbg.Height != 7 || cbg.ID != (types.BlockID{7}) {
+		t.Fatalf("expected confirmation in block 7, got %v (%v)", cbg.Height, cbg.ID)
+	}
+
+	// A cancelled context should stop the polling.
+	atomic.StoreUint64(&polls, 0)
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.WaitForTransaction(ctx, txid, time.Millisecond); !errors.Is(err, context.Canceled) {
+		t.Fatal("expected context.Canceled, got", err)
+	}
+}
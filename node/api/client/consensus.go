@@ -32,6 +32,38 @@ func (c *Client) ConsensusBlocksHeightGet(height types.BlockHeight) (cbg api.Con
 	return
 }
 
+// ConsensusPoWGet requests the /consensus/pow/:height api resource
+func (c *Client) ConsensusPoWGet(height types.BlockHeight) (cpg api.ConsensusPoWGET, err error) {
+	err = c.get(fmt.Sprintf("/consensus/pow/%v", height), &cpg)
+	return
+}
+
+// ConsensusPoWNonceGet requests the /consensus/pow/:height api resource,
+// checking the block's header with its nonce replaced by the provided one.
+func (c *Client) ConsensusPoWNonceGet(height types.BlockHeight, nonce types.BlockNonce) (cpg api.ConsensusPoWGET, err error) {
+	err = c.get(fmt.Sprintf("/consensus/pow/%v?nonce=%x", height, nonce[:]), &cpg)
+	return
+}
+
+// WaitForBlockHeight polls the /consensus endpoint every pollInterval until the
+// node has reached the provided height, returning the consensus state at that
+// point. It returns ctx.Err() if ctx is done first.
+func (c *Client) WaitForBlockHeight(ctx context.Context, height types.BlockHeight, pollInterval time.Duration) (api.ConsensusGET, error) {
+	for {
+		cg, err := c.ConsensusGet()
+		if err != nil {
+			return api.ConsensusGET{}, err
+		} else if cg.Height >= height {
+			return cg, nil
+		}
+		select {
+		case <-ctx.Done():
+			return api.ConsensusGET{}, ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
 // ConsensusSubscribeSingle streams consensus changes from the
 // /consensus/subscribe endpoint to the provided subscriber. Multiple calls may
 // be required before the subscriber is fully caught up. It returns the latest
@@ -30,6 +30,12 @@ func (c *Client) DaemonVersionGet() (dvg api.DaemonVersionGet, err error) {
 	return
 }
 
+// DaemonStatusGet requests the /daemon/status resource.
+func (c *Client) DaemonStatusGet() (dsg api.DaemonStatusGet, err error) {
+	err = c.get("/daemon/status", &dsg)
+	return
+}
+
 // DaemonSettingsGet requests the /daemon/settings api resource.
 func (c *Client) DaemonSettingsGet() (dsg api.DaemonSettingsGet, err error) {
 	err = c.get("/daemon/settings", &dsg)
@@ -0,0 +1,88 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	"go.sia.tech/siad/node/api"
+	"go.sia.tech/siad/types"
+)
+
+// ExplorerBlockTransactionsGet uses the /explorer/blocks/:height/transactions
+// endpoint to get the IDs of the transactions in the block at height.
+func (c *Client) ExplorerBlockTransactionsGet(height types.BlockHeight) (ebtg api.ExplorerBlockTransactionsGET, err error) {
+	err = c.get(fmt.Sprintf("/explorer/blocks/%v/transactions", height), &ebtg)
+	return
+}
+
+// ExplorerBlockTransactionsCountGet uses the
+// /explorer/blocks/:height/transactions/count endpoint to get the number of
+// transactions in the block at height.
+func (c *Client) ExplorerBlockTransactionsCountGet(height types.BlockHeight) (ebtcg api.ExplorerBlockTransactionsCountGET, err error) {
+	err = c.get(fmt.Sprintf("/explorer/blocks/%v/transactions/count", height), &ebtcg)
+	return
+}
+
+// ExplorerContractsHistogramGet uses the /explorer/contracts/histogram
+// endpoint to get the distribution of file contract sizes over the provided
+// byte thresholds. If no thresholds are provided, the server's defaults are
+// used.
+func (c *Client) ExplorerContractsHistogramGet(thresholds ...uint64) (echg api.ExplorerContractsHistogramGET, err error) {
+	fields := make([]string, len(thresholds))
+	for i, t := range thresholds {
+		fields[i] = fmt.Sprint(t)
+	}
+	err = c.get("/explorer/contracts/histogram?buckets="+strings.Join(fields, ","), &echg)
+	return
+}
+
+// ExplorerDifficultyAdjustmentsGet uses the /explorer/difficulty/adjustments
+// endpoint to get the difficulty changes between start and end, inclusive,
+// whose absolute percentage change exceeds threshold.
+func (c *Client) ExplorerDifficultyAdjustmentsGet(start, end types.BlockHeight, threshold float64) (edag api.ExplorerDifficultyAdjustmentsGET, err error) {
+	err = c.get(fmt.Sprintf("/explorer/difficulty/adjustments?start=%v&end=%v&threshold=%v", start, end, threshold), &edag)
+	return
+}
+
+// ExplorerFactsGet uses the /explorer/facts endpoint to get the block facts
+// for every block between start and end, inclusive.
+func (c *Client) ExplorerFactsGet(start, end types.BlockHeight) (efg api.ExplorerFactsGET, err error) {
+	err = c.get(fmt.Sprintf("/explorer/facts?start=%v&end=%v", start, end), &efg)
+	return
+}
+
+// ExplorerHashesGet uses the /explorer/hashes/:hash endpoint to look up the
+// block, transaction or other object identified by hash.
+func (c *Client) ExplorerHashesGet(hash string) (ehg api.ExplorerHashGET, err error) {
+	err = c.get("/explorer/hashes/"+hash, &ehg)
+	return
+}
+
+// ExplorerTransactionDetail uses the /explorer/hashes/:hash endpoint with
+// detail=true to get a transaction along with the transactions that created
+// its siacoin inputs.
+func (c *Client) ExplorerTransactionDetail(id types.TransactionID) (td api.TransactionDetail, err error) {
+	err = c.get(fmt.Sprintf("/explorer/hashes/%v?detail=true", id), &td)
+	return
+}
+
+// ExplorerHistoryGet uses the /explorer/history/:address endpoint to get the
+// balance of addr after every block in which it changed.
+func (c *Client) ExplorerHistoryGet(addr types.UnlockHash) (ehg api.ExplorerHistoryGET, err error) {
+	err = c.get("/explorer/history/"+addr.String(), &ehg)
+	return
+}
+
+// ExplorerLatestFactsGet uses the /explorer/facts/latest endpoint to get the
+// block facts of the last n blocks, starting with the most recent block.
+func (c *Client) ExplorerLatestFactsGet(n int) (efg api.ExplorerFactsGET, err error) {
+	err = c.get(fmt.Sprintf("/explorer/facts/latest?n=%v", n), &efg)
+	return
+}
+
+// ExplorerRevisionsGet uses the /explorer/revisions/:id endpoint to get a file
+// contract along with every revision of it that appeared on the blockchain.
+func (c *Client) ExplorerRevisionsGet(id types.FileContractID) (erg api.ExplorerRevisionsGET, err error) {
+	err = c.get("/explorer/revisions/"+id.String(), &erg)
+	return
+}
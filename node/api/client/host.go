@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/url"
 	"strconv"
+	"time"
 
 	"go.sia.tech/siad/crypto"
 	"go.sia.tech/siad/modules"
@@ -133,6 +134,22 @@ func (c *Client) HostStorageFoldersAddPost(path string, size uint64) (err error)
 	return
 }
 
+// HostStorageFoldersRecheckPost uses the /host/storage/folders/recheck api
+// endpoint to check whether unavailable storage folders have become available.
+func (c *Client) HostStorageFoldersRecheckPost() (err error) {
+	err = c.post("/host/storage/folders/recheck", "", nil)
+	return
+}
+
+// HostStorageSyncIntervalPost uses the /host/storage/syncinterval api endpoint
+// to change how often the host commits storage changes to disk.
+func (c *Client) HostStorageSyncIntervalPost(interval time.Duration) (err error) {
+	values := url.Values{}
+	values.Set("interval", interval.String())
+	err = c.post("/host/storage/syncinterval", values.Encode(), nil)
+	return
+}
+
 // HostStorageFoldersRemovePost uses the /host/storage/folders/remove api
 // endpoint to remove a storage folder from a host.
 func (c *Client) HostStorageFoldersRemovePost(path string, force bool) (err error) {
@@ -1,6 +1,9 @@
 package client
 
 import (
+	"net/url"
+	"strconv"
+
 	"gitlab.com/NebulousLabs/encoding"
 	"go.sia.tech/siad/node/api"
 	"go.sia.tech/siad/types"
@@ -12,6 +15,21 @@ func (c *Client) MinerGet() (mg api.MinerGET, err error) {
 	return
 }
 
+// MinerStatusGet requests the /miner/status endpoint's resources.
+func (c *Client) MinerStatusGet() (msg api.MinerStatusGET, err error) {
+	err = c.get("/miner/status", &msg)
+	return
+}
+
+// MinerAddressPost uses the /miner/address endpoint to set the address that
+// receives the rewards of mined blocks.
+func (c *Client) MinerAddressPost(addr types.UnlockHash) (err error) {
+	values := url.Values{}
+	values.Set("address", addr.String())
+	err = c.post("/miner/address", values.Encode(), nil)
+	return
+}
+
 // MinerBlockPost uses the /miner/block endpoint to submit a solved block.
 func (c *Client) MinerBlockPost(b types.Block) (err error) {
 	err = c.post("/miner/block", string(encoding.Marshal(b)), nil)
@@ -46,3 +64,12 @@ func (c *Client) MinerStopGet() (err error) {
 	err = c.get("/miner/stop", nil)
 	return
 }
+
+// MinerThrottlePost uses the /miner/throttle endpoint to limit the cpu miner
+// to a fraction of a cpu core.
+func (c *Client) MinerThrottlePost(fraction float64) (err error) {
+	values := url.Values{}
+	values.Set("fraction", strconv.FormatFloat(fraction, 'f', -1, 64))
+	err = c.post("/miner/throttle", values.Encode(), nil)
+	return
+}
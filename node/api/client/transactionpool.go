@@ -1,14 +1,31 @@
 package client
 
 import (
+	"context"
 	"encoding/base64"
+	"errors"
 	"net/url"
+	"time"
 
 	"gitlab.com/NebulousLabs/encoding"
 	"go.sia.tech/siad/node/api"
 	"go.sia.tech/siad/types"
 )
 
+// TransactionPoolAddressesGet uses the /tpool/addresses endpoint to get the
+// addresses involved in the transactions of the tpool.
+func (c *Client) TransactionPoolAddressesGet() (tag api.TpoolAddressesGET, err error) {
+	err = c.get("/tpool/addresses", &tag)
+	return
+}
+
+// TransactionPoolAddressGet uses the /tpool/addresses/:address endpoint to get
+// the transactions of the tpool that involve an address.
+func (c *Client) TransactionPoolAddressGet(addr types.UnlockHash) (ttg api.TpoolTxnsGET, err error) {
+	err = c.get("/tpool/addresses/"+addr.String(), &ttg)
+	return
+}
+
 // TransactionPoolFeeGet uses the /tpool/fee endpoint to get a fee estimation.
 func (c *Client) TransactionPoolFeeGet() (tfg api.TpoolFeeGET, err error) {
 	err = c.get("/tpool/fee", &tfg)
@@ -25,6 +42,60 @@ func (c *Client) TransactionPoolRawPost(txn types.Transaction, parents []types.T
 	return
 }
 
+// TransactionPoolStatusGet uses the /tpool/status/:id endpoint to check
+// whether a transaction is in the transaction pool or has been confirmed.
+func (c *Client) TransactionPoolStatusGet(id types.TransactionID) (tsg api.TpoolStatusGET, err error) {
+	err = c.get("/tpool/status/"+id.String(), &tsg)
+	return
+}
+
+// WaitForTransaction polls the /tpool/status/:id endpoint every pollInterval
+// until the transaction has been confirmed, and returns the block that
+// confirmed it. The transaction must be known to the node, either in its
+// transaction pool or on the blockchain. It returns ctx.Err() if ctx is done
+// first.
+func (c *Client) WaitForTransaction(ctx context.Context, id types.TransactionID, pollInterval time.Duration) (api.ConsensusBlocksGet, error) {
+	for {
+		tsg, err := c.TransactionPoolStatusGet(id)
+		if err != nil {
+			return api.ConsensusBlocksGet{}, err
+		} else if tsg.Confirmed {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return api.ConsensusBlocksGet{}, ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+
+	// The transaction pool doesn't record where a transaction was confirmed,
+	// so search for it starting from the most recent block.
+	cg, err := c.ConsensusGet()
+	if err != nil {
+		return api.ConsensusBlocksGet{}, err
+	}
+	for height := cg.Height; ; height-- {
+		select {
+		case <-ctx.Done():
+			return api.ConsensusBlocksGet{}, ctx.Err()
+		default:
+		}
+		cbg, err := c.ConsensusBlocksHeightGet(height)
+		if err != nil {
+			return api.ConsensusBlocksGet{}, err
+		}
+		for _, txn := range cbg.Transactions {
+			if txn.ID == id {
+				return cbg, nil
+			}
+		}
+		if height == 0 {
+			return api.ConsensusBlocksGet{}, errors.New("confirmed transaction was not found in the blockchain")
+		}
+	}
+}
+
 // TransactionPoolTransactionsGet uses the /tpool/transactions endpoint to get the
 // transactions of the tpool
 func (c *Client) TransactionPoolTransactionsGet() (tptg api.TpoolTxnsGET, err error) {
@@ -20,6 +20,21 @@ func (c *Client) WalletAddressGet() (wag api.WalletAddressGET, err error) {
 	return
 }
 
+// WalletAddressAtIndexGet uses the /wallet/address/:index endpoint to derive
+// the address at index from the primary seed, without advancing the seed
+// progress.
+func (c *Client) WalletAddressAtIndexGet(index uint64) (wag api.WalletAddressGET, err error) {
+	err = c.get(fmt.Sprintf("/wallet/address/%v", index), &wag)
+	return
+}
+
+// WalletAddressIndexGet uses the /wallet/addressindex/:addr endpoint to find
+// the index at which the primary seed generates addr.
+func (c *Client) WalletAddressIndexGet(addr types.UnlockHash, maxIndex uint64) (waig api.WalletAddressIndexGET, err error) {
+	err = c.get(fmt.Sprintf("/wallet/addressindex/%v?maxindex=%v", addr, maxIndex), &waig)
+	return
+}
+
 // WalletAddressesGet requests the wallets known addresses from the
 // /wallet/addresses endpoint.
 func (c *Client) WalletAddressesGet() (wag api.WalletAddressesGET, err error) {
@@ -91,6 +106,19 @@ func (c *Client) WalletInitSeedPost(seed, password string, force bool) (err erro
 	return
 }
 
+// WalletInitWeakSeedPost uses the /wallet/init/seed endpoint to initialize
+// the wallet from a seed that fails the entropy check. It should only be used
+// to restore a wallet that was already created from such a seed.
+func (c *Client) WalletInitWeakSeedPost(seed, password string, force bool) (err error) {
+	values := url.Values{}
+	values.Set("seed", seed)
+	values.Set("encryptionpassword", password)
+	values.Set("force", strconv.FormatBool(force))
+	values.Set("allowweakseed", "true")
+	err = c.post("/wallet/init/seed", values.Encode(), nil)
+	return
+}
+
 // WalletGet requests the /wallet api resource
 func (c *Client) WalletGet() (wg api.WalletGET, err error) {
 	err = c.get("/wallet", &wg)
@@ -121,6 +149,13 @@ func (c *Client) WalletSeedPost(seed, password string) (err error) {
 	return
 }
 
+// WalletSeedIndexGet uses the /wallet/seedindex endpoint to return the number
+// of addresses generated from the primary seed.
+func (c *Client) WalletSeedIndexGet() (wsig api.WalletSeedIndexGET, err error) {
+	err = c.get("/wallet/seedindex", &wsig)
+	return
+}
+
 // WalletSeedsGet uses the /wallet/seeds endpoint to return the wallet's
 // current seeds.
 func (c *Client) WalletSeedsGet() (wsg api.WalletSeedsGET, err error) {
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -13,6 +14,7 @@ import (
 	"go.sia.tech/siad/build"
 	"go.sia.tech/siad/crypto"
 	"go.sia.tech/siad/modules"
+	"go.sia.tech/siad/modules/consensus"
 	"go.sia.tech/siad/types"
 )
 
@@ -55,6 +57,15 @@ type ConsensusHeadersGET struct {
 	BlockID types.BlockID `json:"blockid"`
 }
 
+// ConsensusPoWGET contains the result of checking a block's proof-of-work
+// against the target it was mined at.
+type ConsensusPoWGET struct {
+	Valid      bool           `json:"valid"`
+	ID         types.BlockID  `json:"id"`
+	Target     types.Target   `json:"target"`
+	Difficulty types.Currency `json:"difficulty"`
+}
+
 // ConsensusBlocksGet contains all fields of a types.Block and additional
 // fields for ID and Height.
 type ConsensusBlocksGet struct {
@@ -123,6 +134,9 @@ func RegisterRoutesConsensus(router *httprouter.Router, cs modules.ConsensusSet)
 	router.GET("/consensus/blocks", func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
 		consensusBlocksHandler(cs, w, req, ps)
 	})
+	router.GET("/consensus/pow/:height", func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+		consensusPoWHandler(cs, w, req, ps)
+	})
 	router.GET("/consensus/subscribe/:id", func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
 		consensusSubscribeHandler(cs, w, req, ps)
 	})
@@ -323,6 +337,46 @@ func consensusValidateTransactionsetHandler(cs modules.ConsensusSet, w http.Resp
 	WriteSuccess(w)
 }
 
+// consensusPoWHandler handles the API calls to /consensus/pow/:height.
+// If a nonce is provided, it replaces the block's nonce before the
+// proof-of-work is checked.
+func consensusPoWHandler(cs modules.ConsensusSet, w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+	var height types.BlockHeight
+	if _, err := fmt.Sscan(ps.ByName("height"), &height); err != nil {
+		WriteError(w, Error{"failed to parse block height"}, http.StatusBadRequest)
+		return
+	}
+	if height == 0 {
+		WriteError(w, Error{"the genesis block has no proof-of-work"}, http.StatusBadRequest)
+		return
+	}
+	b, exists := cs.BlockAtHeight(height)
+	if !exists {
+		WriteError(w, Error{"block doesn't exist"}, http.StatusBadRequest)
+		return
+	}
+	header := b.Header()
+	if nonce := req.FormValue("nonce"); nonce != "" {
+		nonceBytes, err := hex.DecodeString(nonce)
+		if err != nil || len(nonceBytes) != len(header.Nonce) {
+			WriteError(w, Error{"nonce must be 16 hex characters"}, http.StatusBadRequest)
+			return
+		}
+		copy(header.Nonce[:], nonceBytes)
+	}
+	target, exists := cs.ChildTarget(b.ParentID)
+	if !exists {
+		WriteError(w, Error{"failed to get the target of the block"}, http.StatusInternalServerError)
+		return
+	}
+	WriteJSON(w, ConsensusPoWGET{
+		Valid:      consensus.VerifyPoW(header, target),
+		ID:         header.ID(),
+		Target:     target,
+		Difficulty: target.Difficulty(),
+	})
+}
+
 // consensusSubscribeHandler handles the API calls to the /consensus/subscribe
 // endpoint.
 func consensusSubscribeHandler(cs modules.ConsensusSet, w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
@@ -14,6 +14,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/inconshreveable/go-update"
 
@@ -79,6 +80,9 @@ type (
 		Version     string
 		GitRevision string
 		BuildTime   string
+		GoVersion   string
+		OS          string
+		Arch        string
 	}
 
 	// DaemonUpdateGet contains information about a potential available update for
@@ -139,11 +143,27 @@ type (
 		Modules          configModules `json:"modules"`
 	}
 
+	// DaemonStatusGet contains a summary of the daemon's state for
+	// monitoring purposes. Fields belonging to modules that are not loaded
+	// are left zero.
+	DaemonStatusGet struct {
+		Version           string            `json:"version"`
+		Height            types.BlockHeight `json:"height"`
+		Synced            bool              `json:"synced"`
+		Peers             int               `json:"peers"`
+		TpoolTransactions int               `json:"tpooltransactions"`
+		Uptime            int64             `json:"uptime"`
+		MemoryUsage       uint64            `json:"memoryusage"`
+	}
+
 	// DaemonVersion holds the version information for siad
 	DaemonVersion struct {
 		Version     string `json:"version"`
 		GitRevision string `json:"gitrevision"`
 		BuildTime   string `json:"buildtime"`
+		GoVersion   string `json:"goversion"`
+		OS          string `json:"os"`
+		Arch        string `json:"arch"`
 	}
 )
 
@@ -490,7 +510,37 @@ func (api *API) daemonStopProfileHandlerPOST(w http.ResponseWriter, _ *http.Requ
 
 // daemonVersionHandler handles the API call that requests the daemon's version.
 func (api *API) daemonVersionHandler(w http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
-	WriteJSON(w, DaemonVersion{Version: build.NodeVersion, GitRevision: build.GitRevision, BuildTime: build.BuildTime})
+	WriteJSON(w, DaemonVersion{
+		Version:     build.NodeVersion,
+		GitRevision: build.GitRevision,
+		BuildTime:   build.BuildTime,
+		GoVersion:   runtime.Version(),
+		OS:          runtime.GOOS,
+		Arch:        runtime.GOARCH,
+	})
+}
+
+// daemonStatusHandlerGET handles the API call that requests a summary of the
+// daemon's state.
+func (api *API) daemonStatusHandlerGET(w http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	dsg := DaemonStatusGet{
+		Version:     build.NodeVersion,
+		Uptime:      int64(time.Since(api.StartTime()).Seconds()),
+		MemoryUsage: ms.Sys,
+	}
+	if api.cs != nil {
+		dsg.Height = api.cs.Height()
+		dsg.Synced = api.cs.Synced()
+	}
+	if api.gateway != nil {
+		dsg.Peers = len(api.gateway.Peers())
+	}
+	if api.tpool != nil {
+		dsg.TpoolTransactions = len(api.tpool.TransactionList())
+	}
+	WriteJSON(w, dsg)
 }
 
 // daemonStopHandler handles the API call to stop the daemon cleanly.
@@ -3,6 +3,7 @@ package api
 import (
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/julienschmidt/httprouter"
 
@@ -59,6 +60,37 @@ type (
 		Block ExplorerBlock `json:"block"`
 	}
 
+	// ExplorerBlockTransactionsGET is the object returned by a GET request to
+	// /explorer/blocks/:height/transactions. The IDs are in the order that
+	// the transactions appear in the block.
+	ExplorerBlockTransactionsGET struct {
+		TransactionIDs []types.TransactionID `json:"transactionids"`
+	}
+
+	// ExplorerBlockTransactionsCountGET is the object returned by a GET
+	// request to /explorer/blocks/:height/transactions/count.
+	ExplorerBlockTransactionsCountGET struct {
+		Count int `json:"count"`
+	}
+
+	// ExplorerContractsHistogramGET is the object returned as a response to a
+	// GET request to /explorer/contracts/histogram.
+	ExplorerContractsHistogramGET struct {
+		Buckets []modules.HistogramBucket `json:"buckets"`
+	}
+
+	// ExplorerDifficultyAdjustmentsGET is the object returned as a response to
+	// a GET request to /explorer/difficulty/adjustments.
+	ExplorerDifficultyAdjustmentsGET struct {
+		Adjustments []modules.DifficultyAdjustment `json:"adjustments"`
+	}
+
+	// ExplorerFactsGET is the object returned as a response to a GET request
+	// to /explorer/facts.
+	ExplorerFactsGET struct {
+		Facts []modules.BlockFacts `json:"facts"`
+	}
+
 	// ExplorerHashGET is the object returned as a response to a GET request to
 	// /explorer/hash. The HashType will indicate whether the hash corresponds
 	// to a block id, a transaction id, a siacoin output id, a file contract
@@ -74,8 +106,52 @@ type (
 		Transaction  ExplorerTransaction   `json:"transaction"`
 		Transactions []ExplorerTransaction `json:"transactions"`
 	}
+
+	// TransactionDetail is the object returned as a response to a GET
+	// request to /explorer/hashes/:hash?detail=true. Along with the explorer
+	// transaction, it reports the transaction that created each of the
+	// siacoin outputs spent by the transaction.
+	TransactionDetail struct {
+		ExplorerTransaction
+		InputSources []types.TransactionID `json:"inputsources"`
+	}
+
+	// ExplorerClusterGET is the object returned as a response to a GET
+	// request to /explorer/cluster/:address.
+	ExplorerClusterGET struct {
+		Addresses []types.UnlockHash `json:"addresses"`
+	}
+
+	// ExplorerHistoryGET is the object returned as a response to a GET
+	// request to /explorer/history/:address.
+	ExplorerHistoryGET struct {
+		History []modules.BalanceSnapshot `json:"history"`
+	}
+
+	// ExplorerRevisionsGET is the object returned as a response to a GET
+	// request to /explorer/revisions/:id. Revisions are ordered by revision
+	// number, starting with the first revision after the original contract.
+	ExplorerRevisionsGET struct {
+		FileContract       types.FileContract           `json:"filecontract"`
+		Revisions          []types.FileContractRevision `json:"revisions"`
+		StorageProofExists bool                         `json:"storageproofexists"`
+	}
+
+	// ExplorerRichlistGET is the object returned as a response to a GET
+	// request to /explorer/richlist/siafunds.
+	ExplorerRichlistGET struct {
+		Addresses []modules.AddressBalance `json:"addresses"`
+	}
 )
 
+// defaultRichlistLimit is the number of addresses returned by
+// /explorer/richlist/siafunds if no limit is specified.
+const defaultRichlistLimit = 100
+
+// defaultHistogramThresholds are the bucket thresholds used by
+// /explorer/contracts/histogram if none are specified: 1 MiB, 1 GiB and 1 TiB.
+var defaultHistogramThresholds = []uint64{1 << 20, 1 << 30, 1 << 40}
+
 // RegisterRoutesExplorer is a helper function to register all explorer routes.
 func RegisterRoutesExplorer(router *httprouter.Router, e modules.Explorer, cs modules.ConsensusSet) {
 	router.GET("/explorer", func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
@@ -84,9 +160,113 @@ func RegisterRoutesExplorer(router *httprouter.Router, e modules.Explorer, cs mo
 	router.GET("/explorer/blocks/:height", func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
 		explorerBlocksHandler(e, cs, w, req, ps)
 	})
+	router.GET("/explorer/blocks/:height/transactions", func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+		explorerBlockTransactionsHandler(cs, w, req, ps)
+	})
+	router.GET("/explorer/blocks/:height/transactions/count", func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+		explorerBlockTransactionsCountHandler(cs, w, req, ps)
+	})
+	router.GET("/explorer/cluster/:address", func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+		explorerClusterHandler(e, w, req, ps)
+	})
+	router.GET("/explorer/contracts/histogram", func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+		explorerContractsHistogramHandler(e, w, req, ps)
+	})
+	router.GET("/explorer/difficulty/adjustments", func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+		explorerDifficultyAdjustmentsHandler(e, w, req, ps)
+	})
+	router.GET("/explorer/facts", func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+		explorerFactsHandler(e, w, req, ps)
+	})
+	router.GET("/explorer/facts/latest", func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+		explorerLatestFactsHandler(e, w, req, ps)
+	})
 	router.GET("/explorer/hashes/:hash", func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
 		explorerHashHandler(e, w, req, ps)
 	})
+	router.GET("/explorer/history/:address", func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+		explorerHistoryHandler(e, w, req, ps)
+	})
+	router.GET("/explorer/revisions/:id", func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+		explorerRevisionsHandler(e, w, req, ps)
+	})
+	router.GET("/explorer/richlist/siafunds", func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+		explorerRichlistSiafundsHandler(e, w, req, ps)
+	})
+}
+
+// siacoinOutputSource returns the ID of the transaction that created the
+// siacoin output with the given ID. Besides its creator, an output is
+// associated with the transaction spending it and, for file contract payouts,
+// with every revision of the contract, so each candidate is checked. The
+// source of a miner payout is the ID of its block, and the source of a
+// contract payout is the transaction that formed the contract, unless the
+// payout was only added by a revision.
+func siacoinOutputSource(explorer modules.Explorer, id types.SiacoinOutputID) types.TransactionID {
+	var revision types.TransactionID
+	for _, txid := range explorer.SiacoinOutputID(id) {
+		block, _, exists := explorer.Transaction(txid)
+		if !exists {
+			continue
+		}
+		if types.TransactionID(block.ID()) == txid {
+			for i := range block.MinerPayouts {
+				if block.MinerPayoutID(uint64(i)) == id {
+					return txid
+				}
+			}
+			continue
+		}
+		for _, t := range block.Transactions {
+			if t.ID() != txid {
+				continue
+			}
+			for i := range t.SiacoinOutputs {
+				if t.SiacoinOutputID(uint64(i)) == id {
+					return txid
+				}
+			}
+			for i, fc := range t.FileContracts {
+				if isContractPayout(t.FileContractID(uint64(i)), len(fc.ValidProofOutputs), len(fc.MissedProofOutputs), id) {
+					return txid
+				}
+			}
+			for _, fcr := range t.FileContractRevisions {
+				if revision == (types.TransactionID{}) && isContractPayout(fcr.ParentID, len(fcr.NewValidProofOutputs), len(fcr.NewMissedProofOutputs), id) {
+					revision = txid
+				}
+			}
+		}
+	}
+	return revision
+}
+
+// isContractPayout reports whether id is one of the valid or missed proof
+// outputs of the file contract fcid.
+func isContractPayout(fcid types.FileContractID, valid, missed int, id types.SiacoinOutputID) bool {
+	for i := 0; i < valid; i++ {
+		if fcid.StorageProofOutputID(types.ProofValid, uint64(i)) == id {
+			return true
+		}
+	}
+	for i := 0; i < missed; i++ {
+		if fcid.StorageProofOutputID(types.ProofMissed, uint64(i)) == id {
+			return true
+		}
+	}
+	return false
+}
+
+// buildTransactionDetail builds an explorer transaction and resolves the
+// transaction that created each of its siacoin inputs. Resolving a source
+// loads the blocks of every transaction associated with the output, so this
+// is only done on request.
+func buildTransactionDetail(explorer modules.Explorer, height types.BlockHeight, parent types.BlockID, txn types.Transaction) (td TransactionDetail) {
+	td.ExplorerTransaction = buildExplorerTransaction(explorer, height, parent, txn)
+	for _, sci := range txn.SiacoinInputs {
+		td.InputSources = append(td.InputSources, siacoinOutputSource(explorer, sci.ParentID))
+	}
+	return td
 }
 
 // buildExplorerTransaction takes a transaction and the height + id of the
@@ -231,6 +411,48 @@ func explorerBlocksHandler(e modules.Explorer, cs modules.ConsensusSet, w http.R
 	})
 }
 
+// explorerBlockTransactionsHandler handles API calls to
+// /explorer/blocks/:height/transactions.
+func explorerBlockTransactionsHandler(cs modules.ConsensusSet, w http.ResponseWriter, _ *http.Request, ps httprouter.Params) {
+	var height types.BlockHeight
+	_, err := fmt.Sscan(ps.ByName("height"), &height)
+	if err != nil {
+		WriteError(w, Error{err.Error()}, http.StatusBadRequest)
+		return
+	}
+	block, exists := cs.BlockAtHeight(height)
+	if !exists {
+		WriteError(w, Error{"no block found at input height in call to /explorer/blocks/:height/transactions"}, http.StatusBadRequest)
+		return
+	}
+	txids := make([]types.TransactionID, len(block.Transactions))
+	for i, txn := range block.Transactions {
+		txids[i] = txn.ID()
+	}
+	WriteJSON(w, ExplorerBlockTransactionsGET{
+		TransactionIDs: txids,
+	})
+}
+
+// explorerBlockTransactionsCountHandler handles API calls to
+// /explorer/blocks/:height/transactions/count.
+func explorerBlockTransactionsCountHandler(cs modules.ConsensusSet, w http.ResponseWriter, _ *http.Request, ps httprouter.Params) {
+	var height types.BlockHeight
+	_, err := fmt.Sscan(ps.ByName("height"), &height)
+	if err != nil {
+		WriteError(w, Error{err.Error()}, http.StatusBadRequest)
+		return
+	}
+	block, exists := cs.BlockAtHeight(height)
+	if !exists {
+		WriteError(w, Error{"no block found at input height in call to /explorer/blocks/:height/transactions/count"}, http.StatusBadRequest)
+		return
+	}
+	WriteJSON(w, ExplorerBlockTransactionsCountGET{
+		Count: len(block.Transactions),
+	})
+}
+
 // buildTransactionSet returns the blocks and transactions that are associated
 // with a set of transaction ids.
 func buildTransactionSet(explorer modules.Explorer, txids []types.TransactionID) (txns []ExplorerTransaction, blocks []ExplorerBlock) {
@@ -259,7 +481,12 @@ func buildTransactionSet(explorer modules.Explorer, txids []types.TransactionID)
 }
 
 // explorerHashHandler handles GET requests to /explorer/hash/:hash.
-func explorerHashHandler(explorer modules.Explorer, w http.ResponseWriter, _ *http.Request, ps httprouter.Params) {
+func explorerHashHandler(explorer modules.Explorer, w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+	if req.FormValue("detail") == "true" {
+		explorerTransactionDetailHandler(explorer, w, ps)
+		return
+	}
+
 	// Scan the hash as a hash. If that fails, try scanning the hash as an
 	// address.
 	hash, err := scanHash(ps.ByName("hash"))
@@ -371,3 +598,211 @@ func explorerHandler(explorer modules.Explorer, w http.ResponseWriter, _ *http.R
 		BlockFacts: facts,
 	})
 }
+
+// explorerClusterHandler handles API calls to /explorer/cluster/:address.
+func explorerClusterHandler(explorer modules.Explorer, w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+	addr, err := scanAddress(ps.ByName("address"))
+	if err != nil {
+		WriteError(w, Error{"unable to parse address: " + err.Error()}, http.StatusBadRequest)
+		return
+	}
+	depth := 1
+	if d := req.FormValue("depth"); d != "" {
+		_, err := fmt.Sscan(d, &depth)
+		if err != nil {
+			WriteError(w, Error{"unable to parse depth: " + err.Error()}, http.StatusBadRequest)
+			return
+		}
+	}
+	if depth > modules.MaxAddressClusterDepth {
+		WriteError(w, Error{fmt.Sprintf("depth may not exceed %v", modules.MaxAddressClusterDepth)}, http.StatusBadRequest)
+		return
+	}
+	addresses, err := explorer.AddressCluster(addr, depth)
+	if err != nil {
+		WriteError(w, Error{err.Error()}, http.StatusBadRequest)
+		return
+	}
+	WriteJSON(w, ExplorerClusterGET{
+		Addresses: addresses,
+	})
+}
+
+// explorerTransactionDetailHandler handles GET requests to
+// /explorer/hashes/:hash?detail=true, where hash must be a transaction id.
+func explorerTransactionDetailHandler(explorer modules.Explorer, w http.ResponseWriter, ps httprouter.Params) {
+	hash, err := scanHash(ps.ByName("hash"))
+	if err != nil {
+		WriteError(w, Error{err.Error()}, http.StatusBadRequest)
+		return
+	}
+	txid := types.TransactionID(hash)
+	block, height, exists := explorer.Transaction(txid)
+	if !exists || types.TransactionID(block.ID()) == txid {
+		WriteError(w, Error{"detail is only available for transaction ids"}, http.StatusBadRequest)
+		return
+	}
+	for _, txn := range block.Transactions {
+		if txn.ID() == txid {
+			WriteJSON(w, buildTransactionDetail(explorer, height, block.ID(), txn))
+			return
+		}
+	}
+	WriteError(w, Error{"transaction not found in its block"}, http.StatusInternalServerError)
+}
+
+// explorerHistoryHandler handles API calls to /explorer/history/:address.
+func explorerHistoryHandler(explorer modules.Explorer, w http.ResponseWriter, _ *http.Request, ps httprouter.Params) {
+	addr, err := scanAddress(ps.ByName("address"))
+	if err != nil {
+		WriteError(w, Error{"unable to parse address: " + err.Error()}, http.StatusBadRequest)
+		return
+	}
+	history, err := explorer.BalanceHistory(addr)
+	if err != nil {
+		WriteError(w, Error{"unable to get balance history: " + err.Error()}, http.StatusInternalServerError)
+		return
+	}
+	WriteJSON(w, ExplorerHistoryGET{
+		History: history,
+	})
+}
+
+// explorerFactsHandler handles API calls to /explorer/facts.
+func explorerFactsHandler(explorer modules.Explorer, w http.ResponseWriter, req *http.Request, _ httprouter.Params) {
+	var start, end types.BlockHeight
+	_, err := fmt.Sscan(req.FormValue("start"), &start)
+	if err != nil {
+		WriteError(w, Error{"unable to parse start: " + err.Error()}, http.StatusBadRequest)
+		return
+	}
+	_, err = fmt.Sscan(req.FormValue("end"), &end)
+	if err != nil {
+		WriteError(w, Error{"unable to parse end: " + err.Error()}, http.StatusBadRequest)
+		return
+	}
+	facts, err := explorer.BlockFactsRange(start, end)
+	if err != nil {
+		WriteError(w, Error{err.Error()}, http.StatusBadRequest)
+		return
+	}
+	WriteJSON(w, ExplorerFactsGET{
+		Facts: facts,
+	})
+}
+
+// explorerDifficultyAdjustmentsHandler handles API calls to
+// /explorer/difficulty/adjustments.
+func explorerDifficultyAdjustmentsHandler(explorer modules.Explorer, w http.ResponseWriter, req *http.Request, _ httprouter.Params) {
+	var start, end types.BlockHeight
+	_, err := fmt.Sscan(req.FormValue("start"), &start)
+	if err != nil {
+		WriteError(w, Error{"unable to parse start: " + err.Error()}, http.StatusBadRequest)
+		return
+	}
+	_, err = fmt.Sscan(req.FormValue("end"), &end)
+	if err != nil {
+		WriteError(w, Error{"unable to parse end: " + err.Error()}, http.StatusBadRequest)
+		return
+	}
+	var threshold float64
+	if t := req.FormValue("threshold"); t != "" {
+		_, err = fmt.Sscan(t, &threshold)
+		if err != nil {
+			WriteError(w, Error{"unable to parse threshold: " + err.Error()}, http.StatusBadRequest)
+			return
+		}
+	}
+	adjustments, err := explorer.DifficultyAdjustments(start, end, threshold)
+	if err != nil {
+		WriteError(w, Error{err.Error()}, http.StatusBadRequest)
+		return
+	}
+	WriteJSON(w, ExplorerDifficultyAdjustmentsGET{
+		Adjustments: adjustments,
+	})
+}
+
+// explorerContractsHistogramHandler handles API calls to
+// /explorer/contracts/histogram.
+func explorerContractsHistogramHandler(explorer modules.Explorer, w http.ResponseWriter, req *http.Request, _ httprouter.Params) {
+	thresholds := defaultHistogramThresholds
+	if b := req.FormValue("buckets"); b != "" {
+		thresholds = nil
+		for _, field := range strings.Split(b, ",") {
+			var t uint64
+			_, err := fmt.Sscan(field, &t)
+			if err != nil {
+				WriteError(w, Error{"unable to parse buckets: " + err.Error()}, http.StatusBadRequest)
+				return
+			}
+			thresholds = append(thresholds, t)
+		}
+	}
+	buckets, err := explorer.FileSizeHistogram(thresholds)
+	if err != nil {
+		WriteError(w, Error{err.Error()}, http.StatusBadRequest)
+		return
+	}
+	WriteJSON(w, ExplorerContractsHistogramGET{
+		Buckets: buckets,
+	})
+}
+
+// explorerLatestFactsHandler handles API calls to /explorer/facts/latest.
+func explorerLatestFactsHandler(explorer modules.Explorer, w http.ResponseWriter, req *http.Request, _ httprouter.Params) {
+	var n int
+	_, err := fmt.Sscan(req.FormValue("n"), &n)
+	if err != nil {
+		WriteError(w, Error{"unable to parse n: " + err.Error()}, http.StatusBadRequest)
+		return
+	}
+	facts, err := explorer.LatestBlockFactsN(n)
+	if err != nil {
+		WriteError(w, Error{err.Error()}, http.StatusBadRequest)
+		return
+	}
+	WriteJSON(w, ExplorerFactsGET{
+		Facts: facts,
+	})
+}
+
+// explorerRevisionsHandler handles API calls to /explorer/revisions/:id.
+func explorerRevisionsHandler(explorer modules.Explorer, w http.ResponseWriter, _ *http.Request, ps httprouter.Params) {
+	hash, err := scanHash(ps.ByName("id"))
+	if err != nil {
+		WriteError(w, Error{"unable to parse file contract id: " + err.Error()}, http.StatusBadRequest)
+		return
+	}
+	fc, fcrs, exists, proofExists := explorer.FileContractHistory(types.FileContractID(hash))
+	if !exists {
+		WriteError(w, Error{"file contract not found"}, http.StatusNotFound)
+		return
+	}
+	WriteJSON(w, ExplorerRevisionsGET{
+		FileContract:       fc,
+		Revisions:          fcrs,
+		StorageProofExists: proofExists,
+	})
+}
+
+// explorerRichlistSiafundsHandler handles API calls to
+// /explorer/richlist/siafunds.
+func explorerRichlistSiafundsHandler(explorer modules.Explorer, w http.ResponseWriter, req *http.Request, _ httprouter.Params) {
+	limit := defaultRichlistLimit
+	if l := req.FormValue("limit"); l != "" {
+		_, err := fmt.Sscan(l, &limit)
+		if err != nil {
+			WriteError(w, Error{"unable to parse limit: " + err.Error()}, http.StatusBadRequest)
+			return
+		}
+	}
+	addresses, err := explorer.SiafundRichlist(limit)
+	if err != nil {
+		WriteError(w, Error{err.Error()}, http.StatusBadRequest)
+		return
+	}
+	WriteJSON(w, ExplorerRichlistGET{
+		Addresses: addresses,
+	})
+}
@@ -1,11 +1,117 @@
 package api
 
 import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
 	"testing"
 
+	"github.com/julienschmidt/httprouter"
+	"go.sia.tech/siad/modules"
 	"go.sia.tech/siad/types"
 )
 
+// sourceExplorer is a stub explorer that serves the lookups used to resolve
+// the sources of siacoin inputs.
+type sourceExplorer struct {
+	modules.Explorer
+	blocks    map[types.TransactionID]types.Block
+	outputIDs map[types.SiacoinOutputID][]types.TransactionID
+}
+
+func (e sourceExplorer) SiacoinOutput(types.SiacoinOutputID) (types.SiacoinOutput, bool) {
+	return types.SiacoinOutput{}, true
+}
+
+func (e sourceExplorer) SiacoinOutputID(id types.SiacoinOutputID) []types.TransactionID {
+	return e.outputIDs[id]
+}
+
+func (e sourceExplorer) Transaction(id types.TransactionID) (types.Block, types.BlockHeight, bool) {
+	b, ok := e.blocks[id]
+	return b, 0, ok
+}
+
+// TestSiacoinInputSources checks that the source of a spent output is the
+// transaction that created it, even when the output is also associated with
+// other transactions, as file contract payouts are.
+func TestSiacoinInputSources(t *testing.T) {
+	e := sourceExplorer{
+		blocks:    make(map[types.TransactionID]types.Block),
+		outputIDs: make(map[types.SiacoinOutputID][]types.TransactionID),
+	}
+	addBlock := func(b types.Block) {
+		e.blocks[types.TransactionID(b.ID())] = b
+		for _, txn := range b.Transactions {
+			e.blocks[txn.ID()] = b
+		}
+	}
+	// addOutputID associates txid with id, keeping the set in byte order like
+	// the explorer database does.
+	addOutputID := func(id types.SiacoinOutputID, txid types.TransactionID) {
+		ids := append(e.outputIDs[id], txid)
+		sort.Slice(ids, func(i, j int) bool {
+			return bytes.Compare(ids[i][:], ids[j][:]) < 0
+		})
+		e.outputIDs[id] = ids
+	}
+
+	// Form a file contract and revise it until a revision sorts before the
+	// formation transaction.
+	formation := types.Transaction{
+		FileContracts: []types.FileContract{{
+			ValidProofOutputs:  []types.SiacoinOutput{{Value: types.SiacoinPrecision}},
+			MissedProofOutputs: []types.SiacoinOutput{{Value: types.SiacoinPrecision}},
+		}},
+	}
+	fcid := formation.FileContractID(0)
+	payout := fcid.StorageProofOutputID(types.ProofValid, 0)
+	contractBlock := types.Block{Transactions: []types.Transaction{formation}}
+	addOutputID(payout, formation.ID())
+	for i := uint64(1); e.outputIDs[payout][0] == formation.ID(); i++ {
+		revision := types.Transaction{
+			FileContractRevisions: []types.FileContractRevision{{
+				ParentID:              fcid,
+				NewRevisionNumber:     i,
+				NewValidProofOutputs:  []types.SiacoinOutput{{Value: types.SiacoinPrecision}},
+				NewMissedProofOutputs: []types.SiacoinOutput{{Value: types.SiacoinPrecision}},
+			}},
+		}
+		contractBlock.Transactions = append(contractBlock.Transactions, revision)
+		addOutputID(payout, revision.ID())
+	}
+	addBlock(contractBlock)
+
+	// Spend the contract payout along with a miner payout.
+	minerBlock := types.Block{
+		MinerPayouts: []types.SiacoinOutput{{Value: types.SiacoinPrecision}},
+	}
+	addBlock(minerBlock)
+	addOutputID(minerBlock.MinerPayoutID(0), types.TransactionID(minerBlock.ID()))
+	spend := types.Transaction{
+		SiacoinInputs: []types.SiacoinInput{
+			{ParentID: payout},
+			{ParentID: minerBlock.MinerPayoutID(0)},
+		},
+	}
+	addBlock(types.Block{Transactions: []types.Transaction{spend}})
+	addOutputID(payout, spend.ID())
+	addOutputID(minerBlock.MinerPayoutID(0), spend.ID())
+
+	td := buildTransactionDetail(e, 0, types.BlockID{}, spend)
+	if len(td.InputSources) != 2 {
+		t.Fatal("expected 2 input sources, got", len(td.InputSources))
+	}
+	if td.InputSources[0] != formation.ID() {
+		t.Error("expected contract payout to be sourced from", formation.ID(), "got", td.InputSources[0])
+	}
+	if td.InputSources[1] != types.TransactionID(minerBlock.ID()) {
+		t.Error("expected miner payout to be sourced from", minerBlock.ID(), "got", td.InputSources[1])
+	}
+}
+
 // TestIntegrationExplorerGET probes the GET call to /explorer.
 func TestIntegrationExplorerGET(t *testing.T) {
 	t.Skip("Explorer has deadlock issues")
@@ -81,3 +187,15 @@ func TestIntegrationExplorerHashGet(t *testing.T) {
 		t.Error("wrong block type returned")
 	}
 }
+
+// TestExplorerClusterDepth checks that /explorer/cluster/:address refuses
+// depths beyond modules.MaxAddressClusterDepth.
+func TestExplorerClusterDepth(t *testing.T) {
+	var addr types.UnlockHash
+	req := httptest.NewRequest("GET", fmt.Sprintf("/explorer/cluster/%v?depth=%v", addr, modules.MaxAddressClusterDepth+1), nil)
+	w := httptest.NewRecorder()
+	explorerClusterHandler(sourceExplorer{}, w, req, httprouter.Params{{Key: "address", Value: addr.String()}})
+	if w.Code != http.StatusBadRequest {
+		t.Fatal("expected excessive depth to be refused, got", w.Code)
+	}
+}
@@ -104,12 +104,18 @@ func RegisterRoutesHost(router *httprouter.Router, h modules.Host, deps modules.
 	router.POST("/host/storage/folders/add", RequirePassword(func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
 		storageFoldersAddHandler(h, w, req, ps)
 	}, requiredPassword))
+	router.POST("/host/storage/folders/recheck", RequirePassword(func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+		storageFoldersRecheckHandler(h, w, req, ps)
+	}, requiredPassword))
 	router.POST("/host/storage/folders/remove", RequirePassword(func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
 		storageFoldersRemoveHandler(h, w, req, ps)
 	}, requiredPassword))
 	router.POST("/host/storage/folders/resize", RequirePassword(func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
 		storageFoldersResizeHandler(h, w, req, ps)
 	}, requiredPassword))
+	router.POST("/host/storage/syncinterval", RequirePassword(func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+		storageSyncIntervalHandler(h, w, req, ps)
+	}, requiredPassword))
 	router.POST("/host/storage/sectors/delete/:merkleroot", RequirePassword(func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
 		storageSectorsDeleteHandler(h, w, req, ps)
 	}, requiredPassword))
@@ -528,6 +534,33 @@ func storageFoldersResizeHandler(host modules.Host, w http.ResponseWriter, req *
 	WriteSuccess(w)
 }
 
+// storageFoldersRecheckHandler checks whether any unavailable storage folders
+// have become available again.
+func storageFoldersRecheckHandler(host modules.Host, w http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+	err := host.RecheckStorageFolders()
+	if err != nil {
+		WriteError(w, Error{err.Error()}, http.StatusInternalServerError)
+		return
+	}
+	WriteSuccess(w)
+}
+
+// storageSyncIntervalHandler changes how often the storage manager commits
+// its changes to disk.
+func storageSyncIntervalHandler(host modules.Host, w http.ResponseWriter, req *http.Request, _ httprouter.Params) {
+	interval, err := time.ParseDuration(req.FormValue("interval"))
+	if err != nil {
+		WriteError(w, Error{"unable to parse interval: " + err.Error()}, http.StatusBadRequest)
+		return
+	}
+	err = host.SetSyncInterval(interval)
+	if err != nil {
+		WriteError(w, Error{err.Error()}, http.StatusBadRequest)
+		return
+	}
+	WriteSuccess(w)
+}
+
 // storageFoldersRemoveHandler removes a storage folder from the storage
 // manager.
 func storageFoldersRemoveHandler(host modules.Host, w http.ResponseWriter, req *http.Request, _ httprouter.Params) {
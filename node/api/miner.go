@@ -1,12 +1,15 @@
 package api
 
 import (
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/julienschmidt/httprouter"
 
 	"gitlab.com/NebulousLabs/encoding"
 	"go.sia.tech/siad/modules"
+	"go.sia.tech/siad/modules/miner"
 	"go.sia.tech/siad/types"
 )
 
@@ -14,10 +17,22 @@ type (
 	// MinerGET contains the information that is returned after a GET request
 	// to /miner.
 	MinerGET struct {
-		BlocksMined      int  `json:"blocksmined"`
-		CPUHashrate      int  `json:"cpuhashrate"`
-		CPUMining        bool `json:"cpumining"`
-		StaleBlocksMined int  `json:"staleblocksmined"`
+		BlocksMined      int     `json:"blocksmined"`
+		CPUHashrate      int     `json:"cpuhashrate"`
+		CPUMining        bool    `json:"cpumining"`
+		CPUThrottle      float64 `json:"cputhrottle"`
+		StaleBlocksMined int     `json:"staleblocksmined"`
+	}
+
+	// MinerStatusGET contains the information that is returned after a GET
+	// request to /miner/status.
+	MinerStatusGET struct {
+		CPUHashrate     int            `json:"cpuhashrate"`
+		CPUMining       bool           `json:"cpumining"`
+		Difficulty      types.Currency `json:"difficulty"`
+		Target          types.Target   `json:"target"`
+		NetworkHashrate float64        `json:"networkhashrate"`
+		EstimatedShare  float64        `json:"estimatedshare"`
 	}
 )
 
@@ -26,6 +41,9 @@ func RegisterRoutesMiner(router *httprouter.Router, m modules.Miner, requiredPas
 	router.GET("/miner", func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
 		minerHandler(m, w, req, ps)
 	})
+	router.POST("/miner/address", RequirePassword(func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+		minerAddressHandlerPOST(m, w, req, ps)
+	}, requiredPassword))
 	router.POST("/miner/block", RequirePassword(func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
 		minerBlockHandlerPOST(m, w, req, ps)
 	}, requiredPassword))
@@ -41,6 +59,9 @@ func RegisterRoutesMiner(router *httprouter.Router, m modules.Miner, requiredPas
 	router.GET("/miner/stop", RequirePassword(func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
 		minerStopHandler(m, w, req, ps)
 	}, requiredPassword))
+	router.POST("/miner/throttle", RequirePassword(func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+		minerThrottleHandlerPOST(m, w, req, ps)
+	}, requiredPassword))
 }
 
 // minerHandler handles the API call that queries the miner's status.
@@ -50,11 +71,53 @@ func minerHandler(miner modules.Miner, w http.ResponseWriter, _ *http.Request, _
 		BlocksMined:      blocksMined,
 		CPUHashrate:      miner.CPUHashrate(),
 		CPUMining:        miner.CPUMining(),
+		CPUThrottle:      miner.CPUThrottle(),
 		StaleBlocksMined: staleMined,
 	}
 	WriteJSON(w, mg)
 }
 
+// minerStatusHandlerGET handles the API call that compares the miner's
+// hashrate to the estimated hashrate of the network.
+func minerStatusHandlerGET(m modules.Miner, cs modules.ConsensusSet, w http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+	target, exists := cs.ChildTarget(cs.CurrentBlock().ID())
+	if !exists {
+		WriteError(w, Error{"failed to get the target of the current block"}, http.StatusInternalServerError)
+		return
+	}
+	difficulty := target.Difficulty()
+	hashrate := m.CPUHashrate()
+	networkHashrate := miner.EstimateNetworkHashRate(difficulty, time.Duration(types.BlockFrequency)*time.Second)
+	var share float64
+	if networkHashrate > 0 {
+		share = 100 * float64(hashrate) / networkHashrate
+	}
+	WriteJSON(w, MinerStatusGET{
+		CPUHashrate:     hashrate,
+		CPUMining:       m.CPUMining(),
+		Difficulty:      difficulty,
+		Target:          target,
+		NetworkHashrate: networkHashrate,
+		EstimatedShare:  share,
+	})
+}
+
+// minerAddressHandlerPOST handles the API call that sets the address that
+// receives the rewards of mined blocks.
+func minerAddressHandlerPOST(miner modules.Miner, w http.ResponseWriter, req *http.Request, _ httprouter.Params) {
+	addr, err := scanAddress(req.FormValue("address"))
+	if err != nil {
+		WriteError(w, Error{"unable to parse address: " + err.Error()}, http.StatusBadRequest)
+		return
+	}
+	err = miner.SetPayoutAddress(addr)
+	if err != nil {
+		WriteError(w, Error{err.Error()}, http.StatusInternalServerError)
+		return
+	}
+	WriteSuccess(w)
+}
+
 // minerStartHandler handles the API call that starts the miner.
 func minerStartHandler(miner modules.Miner, w http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
 	miner.StartCPUMining()
@@ -67,6 +130,23 @@ func minerStopHandler(miner modules.Miner, w http.ResponseWriter, _ *http.Reques
 	WriteSuccess(w)
 }
 
+// minerThrottleHandlerPOST handles the API call to limit the cpu usage of the
+// cpu miner.
+func minerThrottleHandlerPOST(miner modules.Miner, w http.ResponseWriter, req *http.Request, _ httprouter.Params) {
+	var fraction float64
+	_, err := fmt.Sscan(req.FormValue("fraction"), &fraction)
+	if err != nil {
+		WriteError(w, Error{"unable to parse fraction: " + err.Error()}, http.StatusBadRequest)
+		return
+	}
+	err = miner.SetCPUThrottle(fraction)
+	if err != nil {
+		WriteError(w, Error{err.Error()}, http.StatusBadRequest)
+		return
+	}
+	WriteSuccess(w)
+}
+
 // minerHeaderHandlerGET handles the API call that retrieves a block header
 // for work.
 func minerHeaderHandlerGET(miner modules.Miner, w http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
@@ -43,6 +43,9 @@ func TestMinerGET(t *testing.T) {
 	if mg.CPUMining != st.server.api.miner.CPUMining() {
 		t.Error("mismatched cpu miner status")
 	}
+	if mg.CPUThrottle != st.server.api.miner.CPUThrottle() {
+		t.Error("mismatched cpu throttle")
+	}
 }
 
 // TestMinerStartStop checks that the miner start and miner stop api endpoints
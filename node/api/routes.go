@@ -1,17 +1,50 @@
 package api
 
 import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/julienschmidt/httprouter"
+	"gitlab.com/NebulousLabs/errors"
 
 	"go.sia.tech/siad/build"
 )
 
+const (
+	// SignatureHeader is the header carrying the HMAC signature of a signed
+	// API request.
+	SignatureHeader = "X-Signature"
+
+	// TimestampHeader is the header carrying the unix timestamp at which a
+	// signed API request was created.
+	TimestampHeader = "X-Timestamp"
+)
+
+var (
+	// maxSignatureAge is the maximum difference between the timestamp of a
+	// signed request and the server's clock. Older requests are rejected to
+	// limit the window in which they can be replayed.
+	maxSignatureAge = 5 * time.Minute
+
+	// maxSignedBodySize is the maximum size of the body of a signed request.
+	// The body is buffered in memory before the signature is checked, so it
+	// is kept small.
+	maxSignedBodySize int64 = 1 << 20 // 1 MiB
+
+	errBadSignature       = errors.New("request signature is invalid")
+	errExpiredSignature   = errors.New("request signature has expired")
+	errSignedBodyTooLarge = errors.New("body of signed request is too large")
+)
+
 var (
 	// httpServerTimeout defines the maximum amount of time before an HTTP call
 	// will timeout and an error will be returned.
@@ -40,6 +73,7 @@ func (api *API) buildHTTPRoutes() {
 	router.POST("/daemon/settings", api.daemonSettingsHandlerPOST)
 	router.GET("/daemon/stack", api.daemonStackHandlerGET)
 	router.POST("/daemon/startprofile", api.daemonStartProfileHandlerPOST)
+	router.GET("/daemon/status", api.daemonStatusHandlerGET)
 	router.GET("/daemon/stop", RequirePassword(api.daemonStopHandler, requiredPassword))
 	router.POST("/daemon/stopprofile", api.daemonStopProfileHandlerPOST)
 	router.GET("/daemon/update", api.daemonUpdateHandlerGET)
@@ -74,6 +108,12 @@ func (api *API) buildHTTPRoutes() {
 	// Miner API Calls
 	if api.miner != nil {
 		RegisterRoutesMiner(router, api.miner, requiredPassword)
+
+		// Register /miner/status separately since it depends on the consensus
+		// set.
+		router.GET("/miner/status", func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+			minerStatusHandlerGET(api.miner, api.cs, w, req, ps)
+		})
 	}
 
 	// Renter API Calls
@@ -172,14 +212,29 @@ func RequireUserAgent(h http.Handler, ua string) http.Handler {
 }
 
 // RequirePassword is middleware that requires a request to authenticate with a
-// password using HTTP basic auth. Usernames are ignored. Empty passwords
-// indicate no authentication is required.
+// password using HTTP basic auth, or by signing the request with the password.
+// Usernames are ignored. Empty passwords indicate no authentication is
+// required.
 func RequirePassword(h httprouter.Handle, password string) httprouter.Handle {
 	// An empty password is equivalent to no password.
 	if password == "" {
 		return h
 	}
 	return func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+		// Signed requests prove knowledge of the password without sending
+		// it. All other requests must provide it through basic auth.
+		if req.Header.Get(SignatureHeader) != "" {
+			err := verifyRequestSignature(w, req, password, time.Now())
+			if errors.Contains(err, errSignedBodyTooLarge) {
+				WriteError(w, Error{"API authentication failed: " + err.Error()}, http.StatusRequestEntityTooLarge)
+				return
+			} else if err != nil {
+				WriteError(w, Error{"API authentication failed: " + err.Error()}, http.StatusUnauthorized)
+				return
+			}
+			h(w, req, ps)
+			return
+		}
 		_, pass, ok := req.BasicAuth()
 		if !ok || pass != password {
 			w.Header().Set("WWW-Authenticate", "Basic realm=\"SiaAPI\"")
@@ -190,6 +245,51 @@ func RequirePassword(h httprouter.Handle, password string) httprouter.Handle {
 	}
 }
 
+// RequestSignature returns the hex encoded HMAC-SHA256 signature of a request,
+// keyed with the API password. The signature covers the method, the request
+// URI including its query string, the timestamp, and the hash of the body.
+func RequestSignature(password, method, uri, timestamp string, body []byte) string {
+	bodyHash := sha256.Sum256(body)
+	mac := hmac.New(sha256.New, []byte(password))
+	mac.Write([]byte(method + uri + timestamp + hex.EncodeToString(bodyHash[:])))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// verifyRequestSignature checks that a signed request was signed with the API
+// password and that its timestamp is recent. The body of the request is read
+// to verify its hash and replaced so that it can be read again by the handler.
+func verifyRequestSignature(w http.ResponseWriter, req *http.Request, password string, now time.Time) error {
+	timestamp := req.Header.Get(TimestampHeader)
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errors.AddContext(errBadSignature, "unable to parse timestamp")
+	}
+	age := now.Sub(time.Unix(unix, 0))
+	if age > maxSignatureAge || age < -maxSignatureAge {
+		return errExpiredSignature
+	}
+	var body []byte
+	if req.Body != nil {
+		body, err = ioutil.ReadAll(http.MaxBytesReader(w, req.Body, maxSignedBodySize))
+		if err != nil && int64(len(body)) >= maxSignedBodySize {
+			return errSignedBodyTooLarge
+		} else if err != nil {
+			return errors.AddContext(err, "unable to read request body")
+		}
+		req.Body.Close()
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	sig, err := hex.DecodeString(req.Header.Get(SignatureHeader))
+	if err != nil {
+		return errBadSignature
+	}
+	expected, _ := hex.DecodeString(RequestSignature(password, req.Method, req.URL.RequestURI(), timestamp, body))
+	if !hmac.Equal(sig, expected) {
+		return errBadSignature
+	}
+	return nil
+}
+
 // isUnrestricted checks if a request may bypass the useragent check.
 func isUnrestricted(req *http.Request) bool {
 	return strings.HasPrefix(req.URL.Path, "/renter/stream/")
@@ -5,7 +5,13 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
 
 	"go.sia.tech/siad/modules"
 	"go.sia.tech/siad/siatest/dependencies"
@@ -190,3 +196,77 @@ func TestAuthentication(t *testing.T) {
 		t.Fatal("authenticated API call failed with the correct password")
 	}
 }
+
+// TestSignedAuthentication tests that RequirePassword accepts requests signed
+// with the API password and rejects tampered, expired or wrongly signed
+// requests.
+func TestSignedAuthentication(t *testing.T) {
+	h := RequirePassword(func(w http.ResponseWriter, req *http.Request, _ httprouter.Params) {
+		// The handler should still be able to read the body.
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil || string(body) != req.URL.Query().Get("body") {
+			WriteError(w, Error{"body was not preserved"}, http.StatusBadRequest)
+			return
+		}
+		WriteSuccess(w)
+	}, "password")
+
+	// signedRequest creates a signed request, signing sigBody instead of the
+	// actual body.
+	signedRequest := func(method, body, sigBody, password string, ts time.Time) *http.Request {
+		uri := "/wallet/seeds?body=" + body
+		req := httptest.NewRequest(method, uri, strings.NewReader(body))
+		timestamp := strconv.FormatInt(ts.Unix(), 10)
+		req.Header.Set(TimestampHeader, timestamp)
+		req.Header.Set(SignatureHeader, RequestSignature(password, method, uri, timestamp, []byte(sigBody)))
+		return req
+	}
+
+	tests := []struct {
+		name string
+		req  *http.Request
+		code int
+	}{
+		{"signed GET", signedRequest("GET", "", "", "password", time.Now()), http.StatusNoContent},
+		{"signed POST", signedRequest("POST", "foo", "foo", "password", time.Now()), http.StatusNoContent},
+		{"tampered body", signedRequest("POST", "foo", "bar", "password", time.Now()), http.StatusUnauthorized},
+		{"wrong password", signedRequest("GET", "", "", "wrong password", time.Now()), http.StatusUnauthorized},
+		{"expired", signedRequest("GET", "", "", "password", time.Now().Add(-2*maxSignatureAge)), http.StatusUnauthorized},
+		{"future", signedRequest("GET", "", "", "password", time.Now().Add(2*maxSignatureAge)), http.StatusUnauthorized},
+	}
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		h(w, test.req, nil)
+		if w.Code != test.code {
+			t.Errorf("%v: expected status %v, got %v: %v", test.name, test.code, w.Code, w.Body.String())
+		}
+	}
+
+	// A signed request should fail if its URI is changed after signing.
+	req := signedRequest("GET", "", "", "password", time.Now())
+	req.URL.RawQuery = "body=&other=true"
+	w := httptest.NewRecorder()
+	h(w, req, nil)
+	if w.Code != http.StatusUnauthorized {
+		t.Error("request with modified URI was accepted", w.Code)
+	}
+
+	// A signed request with a body larger than maxSignedBodySize should be
+	// rejected before its signature is checked.
+	big := strings.Repeat("x", int(maxSignedBodySize)+1)
+	req = signedRequest("POST", big, big, "password", time.Now())
+	w = httptest.NewRecorder()
+	h(w, req, nil)
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Error("oversized signed request was not rejected", w.Code)
+	}
+
+	// Basic auth should still work.
+	req = httptest.NewRequest("GET", "/wallet/seeds", nil)
+	req.SetBasicAuth("", "password")
+	w = httptest.NewRecorder()
+	h(w, req, nil)
+	if w.Code != http.StatusNoContent {
+		t.Error("basic auth request failed", w.Code)
+	}
+}
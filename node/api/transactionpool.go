@@ -29,12 +29,25 @@ type (
 		Transaction []byte              `json:"transaction"`
 	}
 
+	// TpoolAddressesGET contains the addresses involved in the transactions
+	// of the transaction pool.
+	TpoolAddressesGET struct {
+		Addresses []types.UnlockHash `json:"addresses"`
+	}
+
 	// TpoolConfirmedGET contains information about whether or not
 	// the transaction has been seen on the blockhain
 	TpoolConfirmedGET struct {
 		Confirmed bool `json:"confirmed"`
 	}
 
+	// TpoolStatusGET contains information about whether a transaction is
+	// waiting in the transaction pool or has been seen on the blockchain.
+	TpoolStatusGET struct {
+		InPool    bool `json:"inpool"`
+		Confirmed bool `json:"confirmed"`
+	}
+
 	// TpoolTxnsGET contains the information about the tpool's transactions
 	TpoolTxnsGET struct {
 		Transactions []types.Transaction `json:"transactions"`
@@ -44,6 +57,12 @@ type (
 // RegisterRoutesTransactionPool is a helper function to register all
 // transaction pool routes.
 func RegisterRoutesTransactionPool(router *httprouter.Router, tpool modules.TransactionPool) {
+	router.GET("/tpool/addresses", func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+		tpoolAddressesHandlerGET(tpool, w, req, ps)
+	})
+	router.GET("/tpool/addresses/:address", func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+		tpoolAddressHandlerGET(tpool, w, req, ps)
+	})
 	router.GET("/tpool/fee", func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
 		tpoolFeeHandlerGET(tpool, w, req, ps)
 	})
@@ -56,6 +75,9 @@ func RegisterRoutesTransactionPool(router *httprouter.Router, tpool modules.Tran
 	router.GET("/tpool/confirmed/:id", func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
 		tpoolConfirmedGET(tpool, w, req, ps)
 	})
+	router.GET("/tpool/status/:id", func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+		tpoolStatusHandlerGET(tpool, w, req, ps)
+	})
 	router.GET("/tpool/transactions", func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
 		tpoolTransactionsHandler(tpool, w, req, ps)
 	})
@@ -71,6 +93,66 @@ func decodeTransactionID(txidStr string) (types.TransactionID, error) {
 	return types.TransactionID(*txid), nil
 }
 
+// transactionAddresses returns the addresses that a transaction spends from
+// or sends to. Addresses may appear more than once.
+func transactionAddresses(txn types.Transaction) []types.UnlockHash {
+	var addrs []types.UnlockHash
+	for _, sci := range txn.SiacoinInputs {
+		addrs = append(addrs, sci.UnlockConditions.UnlockHash())
+	}
+	for _, sco := range txn.SiacoinOutputs {
+		addrs = append(addrs, sco.UnlockHash)
+	}
+	for _, sfi := range txn.SiafundInputs {
+		addrs = append(addrs, sfi.UnlockConditions.UnlockHash())
+	}
+	for _, sfo := range txn.SiafundOutputs {
+		addrs = append(addrs, sfo.UnlockHash)
+	}
+	return addrs
+}
+
+// tpoolAddressesHandlerGET returns the unique set of addresses involved in
+// the transactions of the transaction pool.
+func tpoolAddressesHandlerGET(tpool modules.TransactionPool, w http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+	seen := make(map[types.UnlockHash]struct{})
+	addrs := []types.UnlockHash{}
+	for _, txn := range tpool.Transactions() {
+		for _, addr := range transactionAddresses(txn) {
+			if _, ok := seen[addr]; ok {
+				continue
+			}
+			seen[addr] = struct{}{}
+			addrs = append(addrs, addr)
+		}
+	}
+	WriteJSON(w, TpoolAddressesGET{
+		Addresses: addrs,
+	})
+}
+
+// tpoolAddressHandlerGET returns the transactions of the transaction pool
+// that involve the specified address.
+func tpoolAddressHandlerGET(tpool modules.TransactionPool, w http.ResponseWriter, _ *http.Request, ps httprouter.Params) {
+	addr, err := scanAddress(ps.ByName("address"))
+	if err != nil {
+		WriteError(w, Error{"error decoding address: " + err.Error()}, http.StatusBadRequest)
+		return
+	}
+	txns := []types.Transaction{}
+	for _, txn := range tpool.Transactions() {
+		for _, uh := range transactionAddresses(txn) {
+			if uh == addr {
+				txns = append(txns, txn)
+				break
+			}
+		}
+	}
+	WriteJSON(w, TpoolTxnsGET{
+		Transactions: txns,
+	})
+}
+
 // tpoolFeeHandlerGET returns the current estimated fee. Transactions with
 // fees are lower than the estimated fee may take longer to confirm.
 func tpoolFeeHandlerGET(tpool modules.TransactionPool, w http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
@@ -160,6 +242,30 @@ func tpoolConfirmedGET(tpool modules.TransactionPool, w http.ResponseWriter, _ *
 	})
 }
 
+// tpoolStatusHandlerGET returns whether the specified transaction is in the
+// transaction pool or has been seen on the blockchain.
+func tpoolStatusHandlerGET(tpool modules.TransactionPool, w http.ResponseWriter, _ *http.Request, ps httprouter.Params) {
+	txid, err := decodeTransactionID(ps.ByName("id"))
+	if err != nil {
+		WriteError(w, Error{"error decoding transaction id: " + err.Error()}, http.StatusBadRequest)
+		return
+	}
+	_, _, inPool := tpool.Transaction(txid)
+	confirmed, err := tpool.TransactionConfirmed(txid)
+	if err != nil {
+		WriteError(w, Error{"error fetching transaction status: " + err.Error()}, http.StatusBadRequest)
+		return
+	}
+	if !inPool && !confirmed {
+		WriteError(w, Error{"transaction not found in transaction pool or blockchain"}, http.StatusNotFound)
+		return
+	}
+	WriteJSON(w, TpoolStatusGET{
+		InPool:    inPool,
+		Confirmed: confirmed,
+	})
+}
+
 // tpoolTransactionsHandler returns the current transactions of the transaction
 // pool
 func tpoolTransactionsHandler(tpool modules.TransactionPool, w http.ResponseWriter, _ *http.Request, ps httprouter.Params) {
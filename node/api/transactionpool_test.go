@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"gitlab.com/NebulousLabs/encoding"
+	"gitlab.com/NebulousLabs/fastrand"
 	"go.sia.tech/siad/build"
 	"go.sia.tech/siad/types"
 )
@@ -256,3 +257,107 @@ func TestTransactionPoolConfirmed(t *testing.T) {
 		t.Fatal("transaction should not be confirmed")
 	}
 }
+
+// TestTransactionPoolStatus tests the /tpool/status endpoint.
+func TestTransactionPoolStatus(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	t.Parallel()
+	st, err := createServerTester(t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.server.panicClose()
+
+	// Create a transaction.
+	sentValue := types.SiacoinPrecision.Mul64(1000)
+	txns, err := st.wallet.SendSiacoins(sentValue, types.UnlockHash{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	txnID := txns[len(txns)-1].ID().String()
+
+	// Transaction should be in the pool but not confirmed.
+	var tsg TpoolStatusGET
+	err = st.getAPI("/tpool/status/"+txnID, &tsg)
+	if err != nil {
+		t.Fatal(err)
+	} else if !tsg.InPool || tsg.Confirmed {
+		t.Fatal("transaction should be in the pool and unconfirmed", tsg)
+	}
+
+	// Mine the block containing the transaction
+	_, err = st.miner.AddBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Transaction should now be confirmed and out of the pool.
+	err = st.getAPI("/tpool/status/"+txnID, &tsg)
+	if err != nil {
+		t.Fatal(err)
+	} else if tsg.InPool || !tsg.Confirmed {
+		t.Fatal("transaction should be confirmed and out of the pool", tsg)
+	}
+
+	// A nonexistent transaction should not be found.
+	badID := strings.Repeat("0", len(txnID))
+	err = st.getAPI("/tpool/status/"+badID, &tsg)
+	if err == nil {
+		t.Fatal("expected an error for an unknown transaction")
+	}
+}
+
+// TestTransactionPoolAddresses tests the /tpool/addresses endpoints.
+func TestTransactionPoolAddresses(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	t.Parallel()
+	st, err := createServerTester(t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.server.panicClose()
+
+	// Send 5 transactions to 3 overlapping addresses.
+	var addrs [3]types.UnlockHash
+	for i := range addrs {
+		fastrand.Read(addrs[i][:])
+	}
+	for _, i := range []int{0, 1, 0, 2, 1} {
+		_, err := st.wallet.SendSiacoins(types.SiacoinPrecision.Mul64(100), addrs[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// All 3 addresses should be reported.
+	var tag TpoolAddressesGET
+	err = st.getAPI("/tpool/addresses", &tag)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, addr := range addrs {
+		found := false
+		for _, uh := range tag.Addresses {
+			found = found || uh == addr
+		}
+		if !found {
+			t.Error("address missing from /tpool/addresses:", addr)
+		}
+	}
+
+	// Each address should map to the transactions that pay it.
+	for i, expected := range []int{2, 2, 1} {
+		var ttg TpoolTxnsGET
+		err = st.getAPI("/tpool/addresses/"+addrs[i].String(), &ttg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ttg.Transactions) != expected {
+			t.Errorf("expected %v transactions for address %v, got %v", expected, i, len(ttg.Transactions))
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"math"
 	"net/http"
 	"path/filepath"
+	"runtime"
 	"strconv"
 	"strings"
 
@@ -15,6 +16,7 @@ import (
 
 	"go.sia.tech/siad/crypto"
 	"go.sia.tech/siad/modules"
+	modWallet "go.sia.tech/siad/modules/wallet"
 	"go.sia.tech/siad/types"
 )
 
@@ -42,6 +44,18 @@ type (
 		Address types.UnlockHash `json:"address"`
 	}
 
+	// WalletAddressIndexGET contains the index at which the primary seed
+	// generates the address passed to /wallet/addressindex/:addr.
+	WalletAddressIndexGET struct {
+		Index uint64 `json:"index"`
+	}
+
+	// WalletSeedIndexGET contains the number of addresses generated from the
+	// primary seed returned by a GET call to /wallet/seedindex.
+	WalletSeedIndexGET struct {
+		Index uint64 `json:"index"`
+	}
+
 	// WalletAddressesGET contains the list of wallet addresses returned by a
 	// GET call to /wallet/addresses.
 	WalletAddressesGET struct {
@@ -171,6 +185,12 @@ func RegisterRoutesWallet(router *httprouter.Router, wallet modules.Wallet, requ
 	router.GET("/wallet/address", RequirePassword(func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
 		walletAddressHandler(wallet, w, req, ps)
 	}, requiredPassword))
+	router.GET("/wallet/address/:index", RequirePassword(func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+		walletAddressAtIndexHandlerGET(wallet, w, req, ps)
+	}, requiredPassword))
+	router.GET("/wallet/addressindex/:addr", RequirePassword(func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+		walletAddressIndexHandlerGET(wallet, w, req, ps)
+	}, requiredPassword))
 	router.GET("/wallet/addresses", func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
 		walletAddressesHandler(wallet, w, req, ps)
 	})
@@ -192,6 +212,9 @@ func RegisterRoutesWallet(router *httprouter.Router, wallet modules.Wallet, requ
 	router.POST("/wallet/seed", RequirePassword(func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
 		walletSeedHandler(wallet, w, req, ps)
 	}, requiredPassword))
+	router.GET("/wallet/seedindex", RequirePassword(func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+		walletSeedIndexHandlerGET(wallet, w, req, ps)
+	}, requiredPassword))
 	router.GET("/wallet/seeds", RequirePassword(func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
 		walletSeedsHandler(wallet, w, req, ps)
 	}, requiredPassword))
@@ -378,6 +401,72 @@ func walletSeedAddressesHandler(wallet modules.Wallet, w http.ResponseWriter, re
 	})
 }
 
+// walletAddressIndexHandlerGET handles API calls to
+// /wallet/addressindex/:addr.
+func walletAddressIndexHandlerGET(wallet modules.Wallet, w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+	var addr types.UnlockHash
+	err := addr.LoadString(ps.ByName("addr"))
+	if err != nil {
+		WriteError(w, Error{"error when calling /wallet/addressindex: " + err.Error()}, http.StatusBadRequest)
+		return
+	}
+	seed, progress, err := wallet.PrimarySeed()
+	if err != nil {
+		WriteError(w, Error{"error when calling /wallet/addressindex: " + err.Error()}, http.StatusBadRequest)
+		return
+	}
+	// Search every index the wallet could know about unless told otherwise.
+	// Larger searches are refused, as they can take arbitrarily long.
+	limit := modWallet.MaxSeedIndexSearch(progress)
+	maxIndex := limit
+	if mi := req.FormValue("maxindex"); mi != "" {
+		_, err := fmt.Sscan(mi, &maxIndex)
+		if err != nil {
+			WriteError(w, Error{"unable to parse maxindex: " + err.Error()}, http.StatusBadRequest)
+			return
+		}
+	}
+	if maxIndex > limit {
+		WriteError(w, Error{fmt.Sprintf("maxindex may not exceed %v", limit)}, http.StatusBadRequest)
+		return
+	}
+	// Stop searching if the client goes away.
+	index, ok := modWallet.FindSeedIndexParallel(seed, addr, maxIndex, runtime.NumCPU(), req.Context().Done())
+	if !ok {
+		WriteError(w, Error{"address is not generated by the primary seed"}, http.StatusNotFound)
+		return
+	}
+	WriteJSON(w, WalletAddressIndexGET{Index: index})
+}
+
+// walletAddressAtIndexHandlerGET handles API calls to /wallet/address/:index.
+func walletAddressAtIndexHandlerGET(wallet modules.Wallet, w http.ResponseWriter, _ *http.Request, ps httprouter.Params) {
+	var index uint64
+	_, err := fmt.Sscan(ps.ByName("index"), &index)
+	if err != nil {
+		WriteError(w, Error{"unable to parse index: " + err.Error()}, http.StatusBadRequest)
+		return
+	}
+	seed, _, err := wallet.PrimarySeed()
+	if err != nil {
+		WriteError(w, Error{"error when calling /wallet/address/:index: " + err.Error()}, http.StatusBadRequest)
+		return
+	}
+	WriteJSON(w, WalletAddressGET{
+		Address: modWallet.SeedUnlockConditions(seed, index).UnlockHash(),
+	})
+}
+
+// walletSeedIndexHandlerGET handles API calls to /wallet/seedindex.
+func walletSeedIndexHandlerGET(wallet modules.Wallet, w http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+	index, err := wallet.PrimarySeedProgress()
+	if err != nil {
+		WriteError(w, Error{"error when calling /wallet/seedindex: " + err.Error()}, http.StatusBadRequest)
+		return
+	}
+	WriteJSON(w, WalletSeedIndexGET{Index: index})
+}
+
 // walletAddressHandler handles API calls to /wallet/addresses.
 func walletAddressesHandler(wallet modules.Wallet, w http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
 	addresses, err := wallet.AllAddresses()
@@ -455,6 +544,14 @@ func walletInitSeedHandler(wallet modules.Wallet, w http.ResponseWriter, req *ht
 		WriteError(w, Error{"error when calling /wallet/init/seed: " + err.Error()}, http.StatusBadRequest)
 		return
 	}
+	// Refuse to create a new wallet from a guessable seed unless the caller
+	// explicitly allows it, e.g. to restore a wallet that already uses one.
+	if req.FormValue("allowweakseed") != "true" {
+		if err := modules.VerifySeedEntropy(seed); err != nil {
+			WriteError(w, Error{"error when calling /wallet/init/seed: " + err.Error()}, http.StatusBadRequest)
+			return
+		}
+	}
 
 	if req.FormValue("force") == "true" {
 		err = wallet.Reset()
@@ -3,6 +3,7 @@ package consensus
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 	"errors"
 	"reflect"
 	"strings"
@@ -59,6 +60,94 @@ func TestApiHeight(t *testing.T) {
 	}
 }
 
+// TestConsensusPoWGet checks that /consensus/pow/:height verifies the
+// proof-of-work of every mined block.
+func TestConsensusPoWGet(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	testDir := consensusTestDir(t.Name())
+
+	// Create a new server
+	testNode, err := siatest.NewNode(node.AllModules(testDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := testNode.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	if err := testNode.MineBlock(); err != nil {
+		t.Fatal(err)
+	}
+	cg, err := testNode.ConsensusGet()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for height := types.BlockHeight(1); height <= cg.Height; height++ {
+		cpg, err := testNode.ConsensusPoWGet(height)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cbg, err := testNode.ConsensusBlocksHeightGet(height)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !cpg.Valid {
+			t.Fatalf("block at height %v failed verification", height)
+		}
+		if cpg.ID != cbg.ID {
+			t.Fatalf("expected ID %v, got %v", cbg.ID, cpg.ID)
+		}
+		if !cpg.Difficulty.Equals(cpg.Target.Difficulty()) {
+			t.Fatal("difficulty does not match target")
+		}
+	}
+
+	// Replacing the nonce of the tip block should eventually produce a header
+	// that fails verification.
+	cbg, err := testNode.ConsensusBlocksHeightGet(cg.Height)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cpg, err := testNode.ConsensusPoWNonceGet(cg.Height, cbg.Nonce)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cpg.Valid || cpg.ID != cbg.ID {
+		t.Fatal("block failed verification with its own nonce")
+	}
+	invalid := false
+	for i := uint64(0); i < 64 && !invalid; i++ {
+		var nonce types.BlockNonce
+		binary.LittleEndian.PutUint64(nonce[:], i)
+		if nonce == cbg.Nonce {
+			continue
+		}
+		cpg, err := testNode.ConsensusPoWNonceGet(cg.Height, nonce)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cpg.ID == cbg.ID {
+			t.Fatal("changing the nonce didn't change the ID")
+		}
+		invalid = !cpg.Valid
+	}
+	if !invalid {
+		t.Fatal("every modified nonce passed verification")
+	}
+
+	// The genesis block and blocks past the tip can't be verified.
+	if _, err := testNode.ConsensusPoWGet(0); err == nil {
+		t.Fatal("expected an error for the genesis block")
+	}
+	if _, err := testNode.ConsensusPoWGet(cg.Height + 1); err == nil {
+		t.Fatal("expected an error for a block that doesn't exist")
+	}
+}
+
 // TestConsensusBlocksIDGet tests the /consensus/blocks endpoint
 func TestConsensusBlocksIDGet(t *testing.T) {
 	if testing.Short() {
@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 	"time"
@@ -16,6 +17,7 @@ import (
 	"go.sia.tech/siad/node/api/client"
 	"go.sia.tech/siad/profile"
 	"go.sia.tech/siad/siatest"
+	"go.sia.tech/siad/types"
 )
 
 // TestDaemonAPIPassword makes sure that the daemon rejects requests with the
@@ -54,6 +56,45 @@ func TestDaemonAPIPassword(t *testing.T) {
 	}
 }
 
+// TestDaemonVersion makes sure that the version endpoint reports the build
+// environment and does not require the API password.
+func TestDaemonVersion(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	testDir := daemonTestDir(t.Name())
+
+	// Create a new server
+	testNode, err := siatest.NewCleanNode(node.Gateway(testDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := testNode.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	// Make a manual API request without a password.
+	opts, err := client.DefaultOptions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts.Address = testNode.Server.APIAddress()
+	opts.Password = ""
+	c := client.New(opts)
+	dvg, err := c.DaemonVersionGet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dvg.Version != build.NodeVersion {
+		t.Errorf("expected version %v, got %v", build.NodeVersion, dvg.Version)
+	}
+	if dvg.GoVersion != runtime.Version() || dvg.OS != runtime.GOOS || dvg.Arch != runtime.GOARCH {
+		t.Errorf("wrong build environment: %v %v %v", dvg.GoVersion, dvg.OS, dvg.Arch)
+	}
+}
+
 // TestDaemonRatelimit makes sure that we can set the daemon's global
 // ratelimits using the API and that they are persisted correctly.
 func TestDaemonRatelimit(t *testing.T) {
@@ -368,3 +409,57 @@ func TestDaemonProfile(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+// TestDaemonStatus tests the /daemon/status endpoint.
+func TestDaemonStatus(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	testDir := daemonTestDir(t.Name())
+
+	// Create a new server
+	testNode, err := siatest.NewNode(node.AllModules(testDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := testNode.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	// Put a transaction in the pool and give the uptime a chance to
+	// increase.
+	wag, err := testNode.WalletAddressGet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := testNode.WalletSiacoinsPost(types.SiacoinPrecision, wag.Address, false); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Second)
+
+	dsg, err := testNode.DaemonStatusGet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	height, err := testNode.BlockHeight()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dsg.Version != build.NodeVersion {
+		t.Errorf("expected version %v, got %v", build.NodeVersion, dsg.Version)
+	}
+	if dsg.Height != height || !dsg.Synced {
+		t.Errorf("expected synced height %v, got %v (synced %v)", height, dsg.Height, dsg.Synced)
+	}
+	if dsg.TpoolTransactions == 0 {
+		t.Error("expected transactions in the pool")
+	}
+	if dsg.Uptime <= 0 {
+		t.Error("expected a positive uptime, got", dsg.Uptime)
+	}
+	if dsg.MemoryUsage == 0 {
+		t.Error("expected a positive memory usage")
+	}
+}
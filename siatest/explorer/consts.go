@@ -0,0 +1,19 @@
+package explorer
+
+import (
+	"os"
+
+	"go.sia.tech/siad/persist"
+	"go.sia.tech/siad/siatest"
+)
+
+// explorerTestDir creates a temporary testing directory for an explorer test.
+// This should only every be called once per test. Otherwise it will delete the
+// directory again.
+func explorerTestDir(testName string) string {
+	path := siatest.TestDir("explorer", testName)
+	if err := os.MkdirAll(path, persist.DefaultDiskPermissionsTest); err != nil {
+		panic(err)
+	}
+	return path
+}
@@ -0,0 +1,313 @@
+package explorer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"gitlab.com/NebulousLabs/fastrand"
+
+	"go.sia.tech/siad/crypto"
+	"go.sia.tech/siad/node"
+	"go.sia.tech/siad/siatest"
+	"go.sia.tech/siad/types"
+)
+
+// TestExplorerBlockTransactions checks that /explorer/blocks/:height/transactions
+// returns the IDs of a block's transactions in block order.
+func TestExplorerBlockTransactions(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	t.Parallel()
+
+	params := node.AllModules(explorerTestDir(t.Name()))
+	params.CreateExplorer = true
+	tn, err := siatest.NewNode(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := tn.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	// Send 5 transactions and mine them into a block.
+	var sent []types.TransactionID
+	for i := 0; i < 5; i++ {
+		wag, err := tn.WalletAddressGet()
+		if err != nil {
+			t.Fatal(err)
+		}
+		wsp, err := tn.WalletSiacoinsPost(types.SiacoinPrecision, wag.Address, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sent = append(sent, wsp.TransactionIDs[len(wsp.TransactionIDs)-1])
+	}
+	if err := tn.MineBlock(); err != nil {
+		t.Fatal(err)
+	}
+	height, err := tn.BlockHeight()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ebtg, err := tn.ExplorerBlockTransactionsGet(height)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cbg, err := tn.ConsensusBlocksHeightGet(height)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ebtg.TransactionIDs) != len(cbg.Transactions) {
+		t.Fatalf("expected %v transactions, got %v", len(cbg.Transactions), len(ebtg.TransactionIDs))
+	}
+	included := make(map[types.TransactionID]bool)
+	for i, txn := range cbg.Transactions {
+		if ebtg.TransactionIDs[i] != txn.ID {
+			t.Fatalf("transaction %v: expected %v, got %v", i, txn.ID, ebtg.TransactionIDs[i])
+		}
+		included[txn.ID] = true
+	}
+	for _, txid := range sent {
+		if !included[txid] {
+			t.Fatal("sent transaction missing from block:", txid)
+		}
+	}
+
+	// The count should match the number of IDs.
+	ebtcg, err := tn.ExplorerBlockTransactionsCountGet(height)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ebtcg.Count != len(cbg.Transactions) {
+		t.Fatalf("expected a count of %v, got %v", len(cbg.Transactions), ebtcg.Count)
+	}
+
+	// A height past the tip should be rejected.
+	if _, err := tn.ExplorerBlockTransactionsGet(height + 1); err == nil {
+		t.Fatal("expected an error for a block that doesn't exist")
+	}
+	if _, err := tn.ExplorerBlockTransactionsCountGet(height + 1); err == nil {
+		t.Fatal("expected an error counting a block that doesn't exist")
+	}
+}
+
+// TestExplorerInputSources checks that the detail of a transaction reports the
+// transactions that created each of its siacoin inputs.
+func TestExplorerInputSources(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	t.Parallel()
+
+	params := node.AllModules(explorerTestDir(t.Name()))
+	params.CreateExplorer = true
+	tn, err := siatest.NewNode(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := tn.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	// spend creates and submits a transaction that spends the provided
+	// outputs to new wallet addresses, one for each value.
+	outputAddrs := make(map[types.SiacoinOutputID]types.UnlockHash)
+	spend := func(ids []types.SiacoinOutputID, values ...types.Currency) types.Transaction {
+		var txn types.Transaction
+		var toSign []crypto.Hash
+		for _, id := range ids {
+			wucg, err := tn.WalletUnlockConditionsGet(outputAddrs[id])
+			if err != nil {
+				t.Fatal(err)
+			}
+			txn.SiacoinInputs = append(txn.SiacoinInputs, types.SiacoinInput{
+				ParentID:         id,
+				UnlockConditions: wucg.UnlockConditions,
+			})
+			txn.TransactionSignatures = append(txn.TransactionSignatures, types.TransactionSignature{
+				ParentID:      crypto.Hash(id),
+				CoveredFields: types.CoveredFields{WholeTransaction: true},
+			})
+			toSign = append(toSign, crypto.Hash(id))
+		}
+		for _, value := range values {
+			wag, err := tn.WalletAddressGet()
+			if err != nil {
+				t.Fatal(err)
+			}
+			txn.SiacoinOutputs = append(txn.SiacoinOutputs, types.SiacoinOutput{
+				Value:      value,
+				UnlockHash: wag.Address,
+			})
+		}
+		wspr, err := tn.WalletSignPost(txn, toSign)
+		if err != nil {
+			t.Fatal(err)
+		}
+		txn = wspr.Transaction
+		for i, sco := range txn.SiacoinOutputs {
+			outputAddrs[txn.SiacoinOutputID(uint64(i))] = sco.UnlockHash
+		}
+		if err := tn.TransactionPoolRawPost(txn, nil); err != nil {
+			t.Fatal(err)
+		}
+		return txn
+	}
+
+	// Fund an address from one of the miner's outputs, split it into two
+	// outputs, and consolidate them again.
+	wug, err := tn.WalletUnspentGet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	output := wug.Outputs[0]
+	outputAddrs[types.SiacoinOutputID(output.ID)] = output.UnlockHash
+	half := output.Value.Div64(2)
+	fund := spend([]types.SiacoinOutputID{types.SiacoinOutputID(output.ID)}, output.Value)
+	split := spend([]types.SiacoinOutputID{fund.SiacoinOutputID(0)}, half, output.Value.Sub(half))
+	consolidate := spend([]types.SiacoinOutputID{split.SiacoinOutputID(0), split.SiacoinOutputID(1)}, output.Value)
+	if err := tn.MineBlock(); err != nil {
+		t.Fatal(err)
+	}
+
+	sources := func(txn types.Transaction) []types.TransactionID {
+		td, err := tn.ExplorerTransactionDetail(txn.ID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if td.ID != txn.ID() {
+			t.Fatal("wrong transaction returned:", td.ID)
+		}
+		return td.InputSources
+	}
+	if s := sources(fund); len(s) != 1 || s[0] == (types.TransactionID{}) || s[0] == fund.ID() {
+		t.Fatal("unexpected input sources for funding transaction:", s)
+	}
+	if s := sources(split); len(s) != 1 || s[0] != fund.ID() {
+		t.Fatal("expected split to be funded by", fund.ID(), "got", s)
+	}
+	if s := sources(consolidate); len(s) != 2 || s[0] != split.ID() || s[1] != split.ID() {
+		t.Fatal("expected consolidation to be funded by", split.ID(), "got", s)
+	}
+
+	// Detail is only available for transactions.
+	cbg, err := tn.ConsensusGet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tn.ExplorerTransactionDetail(types.TransactionID(cbg.CurrentBlock)); err == nil {
+		t.Fatal("expected an error requesting the detail of a block")
+	}
+}
+
+// TestExplorerSync checks that an explorer running on a separate node follows
+// the blocks mined by its peers.
+func TestExplorerSync(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	t.Parallel()
+
+	// Create a group with a miner and a node running an explorer. The group
+	// funds every node, so the explorer node needs a wallet as well.
+	testDir := explorerTestDir(t.Name())
+	explorerParams := node.Wallet(filepath.Join(testDir, "explorer"))
+	explorerParams.CreateExplorer = true
+	tg, err := siatest.NewGroup(testDir, node.Miner(filepath.Join(testDir, "miner")), explorerParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := tg.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	miner := tg.Miners()[0]
+	var explorer *siatest.TestNode
+	for _, tn := range tg.Nodes() {
+		if tn != miner {
+			explorer = tn
+		}
+	}
+
+	// Mine a block and wait for the explorer node to catch up.
+	if err := miner.MineBlock(); err != nil {
+		t.Fatal(err)
+	}
+	if err := tg.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	cg, err := miner.ConsensusGet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	efg, err := explorer.ExplorerLatestFactsGet(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(efg.Facts) != 1 {
+		t.Fatal("expected 1 block facts, got", len(efg.Facts))
+	}
+	if efg.Facts[0].Height != cg.Height || efg.Facts[0].BlockID != cg.CurrentBlock {
+		t.Fatalf("explorer tip %v at height %v does not match miner tip %v at height %v", efg.Facts[0].BlockID, efg.Facts[0].Height, cg.CurrentBlock, cg.Height)
+	}
+}
+
+// TestExplorerHistory checks that /explorer/history/:address reports the
+// balance of an address after each block that changed it.
+func TestExplorerHistory(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	t.Parallel()
+
+	params := node.AllModules(explorerTestDir(t.Name()))
+	params.CreateExplorer = true
+	tn, err := siatest.NewNode(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := tn.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	// Send to a fresh address in two separate blocks.
+	var addr types.UnlockHash
+	fastrand.Read(addr[:])
+	var heights []types.BlockHeight
+	for i := 0; i < 2; i++ {
+		if _, err := tn.WalletSiacoinsPost(types.SiacoinPrecision, addr, false); err != nil {
+			t.Fatal(err)
+		}
+		if err := tn.MineBlock(); err != nil {
+			t.Fatal(err)
+		}
+		height, err := tn.BlockHeight()
+		if err != nil {
+			t.Fatal(err)
+		}
+		heights = append(heights, height)
+	}
+
+	ehg, err := tn.ExplorerHistoryGet(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ehg.History) != len(heights) {
+		t.Fatalf("expected %v snapshots, got %v", len(heights), len(ehg.History))
+	}
+	for i, snapshot := range ehg.History {
+		expected := types.SiacoinPrecision.Mul64(uint64(i + 1))
+		if snapshot.Height != heights[i] || !snapshot.SiacoinBalance.Equals(expected) {
+			t.Errorf("snapshot %v: expected %v at height %v, got %v at height %v", i, expected, heights[i], snapshot.SiacoinBalance, snapshot.Height)
+		}
+	}
+}
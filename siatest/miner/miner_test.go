@@ -3,7 +3,10 @@ package miner
 import (
 	"testing"
 
+	"gitlab.com/NebulousLabs/fastrand"
+
 	"go.sia.tech/siad/siatest"
+	"go.sia.tech/siad/types"
 
 	"go.sia.tech/siad/node"
 )
@@ -37,3 +40,85 @@ func TestMinerEmptyBlock(t *testing.T) {
 		t.Fatalf("new blockheight should be %v but was %v", bh+1, newBH)
 	}
 }
+
+// TestMinerAddress tests that the payout address of the miner can be changed
+// at runtime and that mined blocks pay out to the new address.
+func TestMinerAddress(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	t.Parallel()
+	// Create a miner for testing.
+	m, err := siatest.NewNode(node.AllModules(minerTestDir(t.Name())))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := m.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	// Mine a block to each of two addresses in turn.
+	var addrA, addrB types.UnlockHash
+	fastrand.Read(addrA[:])
+	fastrand.Read(addrB[:])
+	for _, addr := range []types.UnlockHash{addrA, addrB} {
+		if err := m.MinerAddressPost(addr); err != nil {
+			t.Fatal(err)
+		}
+		if err := m.MineBlock(); err != nil {
+			t.Fatal(err)
+		}
+		bh, err := m.BlockHeight()
+		if err != nil {
+			t.Fatal(err)
+		}
+		cbg, err := m.ConsensusBlocksHeightGet(bh)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(cbg.MinerPayouts) != 1 || cbg.MinerPayouts[0].UnlockHash != addr {
+			t.Fatalf("block reward was not paid to %v: %v", addr, cbg.MinerPayouts)
+		}
+	}
+}
+
+// TestMinerStatus tests that /miner/status reports the current network
+// difficulty along with the resulting network hashrate estimate.
+func TestMinerStatus(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	t.Parallel()
+	// Create a miner for testing.
+	m, err := siatest.NewNode(node.AllModules(minerTestDir(t.Name())))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := m.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	msg, err := m.MinerStatusGet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cg, err := m.ConsensusGet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !msg.Difficulty.Equals(cg.Difficulty) || msg.Target != cg.Target {
+		t.Fatalf("status difficulty %v does not match consensus difficulty %v", msg.Difficulty, cg.Difficulty)
+	}
+	difficulty, _ := cg.Difficulty.Float64()
+	expected := difficulty / float64(types.BlockFrequency)
+	if msg.NetworkHashrate != expected {
+		t.Fatalf("expected network hashrate %v, got %v", expected, msg.NetworkHashrate)
+	}
+	if msg.CPUMining || msg.EstimatedShare != 0 {
+		t.Fatalf("idle miner should not have a share of the network: %v", msg.EstimatedShare)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"go.sia.tech/siad/crypto"
 	"go.sia.tech/siad/node"
 	"go.sia.tech/siad/siatest"
 	"go.sia.tech/siad/types"
@@ -72,3 +73,86 @@ func TestTpoolTransactionsGet(t *testing.T) {
 		t.Fatal("expected no transactions got", len(tptg.Transactions))
 	}
 }
+
+// TestTpoolRawPostDuplicate checks that submitting the same transaction twice
+// to /tpool/raw succeeds both times without duplicating it in the pool, while
+// a conflicting transaction is still rejected.
+func TestTpoolRawPostDuplicate(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+
+	// Create testing directory.
+	testdir := tpoolTestDir(t.Name())
+
+	// Create a miner
+	miner, err := siatest.NewNode(node.Miner(filepath.Join(testdir, "miner")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := miner.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	// Create a signed transaction that spends one of the miner's outputs to
+	// the provided address.
+	wug, err := miner.WalletUnspentGet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	output := wug.Outputs[0]
+	wucg, err := miner.WalletUnlockConditionsGet(output.UnlockHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spend := func(dest types.UnlockHash) types.Transaction {
+		txn := types.Transaction{
+			SiacoinInputs: []types.SiacoinInput{{
+				ParentID:         types.SiacoinOutputID(output.ID),
+				UnlockConditions: wucg.UnlockConditions,
+			}},
+			SiacoinOutputs: []types.SiacoinOutput{{
+				Value:      output.Value,
+				UnlockHash: dest,
+			}},
+			TransactionSignatures: []types.TransactionSignature{{
+				ParentID:      crypto.Hash(output.ID),
+				CoveredFields: types.CoveredFields{WholeTransaction: true},
+			}},
+		}
+		wspr, err := miner.WalletSignPost(txn, []crypto.Hash{crypto.Hash(output.ID)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return wspr.Transaction
+	}
+
+	// Submitting the same transaction twice should succeed both times.
+	txn := spend(types.UnlockHash{})
+	if err := miner.TransactionPoolRawPost(txn, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := miner.TransactionPoolRawPost(txn, nil); err != nil {
+		t.Fatal("resubmitting a transaction should succeed:", err)
+	}
+	tptg, err := miner.TransactionPoolTransactionsGet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var found int
+	for _, tpoolTxn := range tptg.Transactions {
+		if tpoolTxn.ID() == txn.ID() {
+			found++
+		}
+	}
+	if found != 1 {
+		t.Fatalf("expected transaction to be in the pool once, found %v times", found)
+	}
+
+	// A different transaction spending the same output should be rejected.
+	if err := miner.TransactionPoolRawPost(spend(types.UnlockHash{1}), nil); err == nil {
+		t.Fatal("expected conflicting transaction to be rejected")
+	}
+}
@@ -969,3 +969,146 @@ func TestWalletVerifyPassword(t *testing.T) {
 		t.Error("Password should not be valid")
 	}
 }
+
+// TestWalletAddressIndex tests that /wallet/addressindex/:addr finds the index
+// at which the primary seed generated an address.
+func TestWalletAddressIndex(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+
+	// Create a new server
+	testNode, err := siatest.NewCleanNode(node.AllModules(siatest.TestDir(t.Name())))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := testNode.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	// Generate some addresses and look up each of their indices.
+	n := 10
+	for i := 0; i < n; i++ {
+		wag, err := testNode.WalletAddressGet()
+		if err != nil {
+			t.Fatal(err)
+		}
+		waig, err := testNode.WalletAddressIndexGet(wag.Address, uint64(n))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if waig.Index != uint64(i) {
+			t.Fatalf("expected address %v to have index %v, got %v", wag.Address, i, waig.Index)
+		}
+	}
+
+	// An address that wasn't generated by the seed shouldn't be found.
+	var addr types.UnlockHash
+	fastrand.Read(addr[:])
+	if _, err := testNode.WalletAddressIndexGet(addr, uint64(n)); err == nil {
+		t.Fatal("expected unknown address to not be found")
+	}
+
+	// Searches beyond the wallet's lookahead should be refused.
+	if _, err := testNode.WalletAddressIndexGet(addr, math.MaxUint64); err == nil || !strings.Contains(err.Error(), "maxindex may not exceed") {
+		t.Fatal("expected an unbounded search to be refused, got", err)
+	}
+}
+
+// TestWalletSeedIndex tests the /wallet/seedindex and /wallet/address/:index
+// endpoints.
+func TestWalletSeedIndex(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+
+	// Create a new server
+	testNode, err := siatest.NewCleanNode(node.AllModules(siatest.TestDir(t.Name())))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := testNode.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	wsig, err := testNode.WalletSeedIndexGet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := wsig.Index
+
+	// Every new address should increment the seed index and match the
+	// address derived at that index.
+	for i := uint64(0); i < 5; i++ {
+		wag, err := testNode.WalletAddressGet()
+		if err != nil {
+			t.Fatal(err)
+		}
+		wsig, err := testNode.WalletSeedIndexGet()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if wsig.Index != start+i+1 {
+			t.Fatalf("expected seed index %v, got %v", start+i+1, wsig.Index)
+		}
+		waig, err := testNode.WalletAddressAtIndexGet(start + i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if waig.Address != wag.Address {
+			t.Fatalf("expected address %v at index %v, got %v", wag.Address, start+i, waig.Address)
+		}
+	}
+
+	// Deriving an address at an explicit index shouldn't advance the seed
+	// index.
+	if _, err := testNode.WalletAddressAtIndexGet(1000); err != nil {
+		t.Fatal(err)
+	}
+	wsig, err = testNode.WalletSeedIndexGet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wsig.Index != start+5 {
+		t.Fatalf("expected seed index %v, got %v", start+5, wsig.Index)
+	}
+}
+
+// TestWalletInitWeakSeed checks that /wallet/init/seed rejects a seed without
+// enough entropy unless it is explicitly allowed, and that a wallet created
+// from such a seed can still be unlocked with it.
+func TestWalletInitWeakSeed(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	// Create a new server
+	testNode, err := siatest.NewNode(node.AllModules(walletTestDir(t.Name())))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := testNode.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	seedStr, err := modules.SeedToString(modules.Seed{}, mnemonics.DictionaryID("english"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = testNode.WalletInitSeedPost(seedStr, "", true)
+	if err == nil || !strings.Contains(err.Error(), modules.ErrWeakSeed.Error()) {
+		t.Fatal("expected weak seed to be rejected, got", err)
+	}
+	if err := testNode.WalletInitWeakSeedPost(seedStr, "", true); err != nil {
+		t.Fatal(err)
+	}
+	// The password defaults to the seed, which must still be accepted.
+	if err := testNode.WalletUnlockPost(seedStr); err != nil {
+		t.Fatal("failed to unlock wallet with a weak seed:", err)
+	}
+}